@@ -0,0 +1,34 @@
+//go:build !strings2_safe && !asan && !msan
+
+package strings2
+
+import "unsafe"
+
+// safeAlloc reports whether MakeNoZero and memclr use the safe fallback in
+// alloc_safe.go instead of runtime internals.
+const safeAlloc = false
+
+//go:linkname mallocgc runtime.mallocgc
+func mallocgc(size uintptr, typ unsafe.Pointer, needzero bool) unsafe.Pointer
+
+func MakeNoZero(l int) []byte {
+	return unsafe.Slice((*byte)(mallocgc(uintptr(l), nil, false)), l)
+}
+
+func MakeNoZeroCap(l int, c int) []byte {
+	return MakeNoZero(c)[:l]
+}
+
+//go:linkname memclrNoHeapPointers runtime.memclrNoHeapPointers
+func memclrNoHeapPointers(p unsafe.Pointer, n uintptr)
+
+// MemclrZero sets memory of slice to zero, assuming T has no heap pointers.
+// T MUST NOT contain any references (e.g. pointers, strings, slices, maps, funcs).
+func memclr[T any](s []T) {
+	if len(s) == 0 {
+		return
+	}
+	size := unsafe.Sizeof(s[0]) * uintptr(len(s))
+	ptr := unsafe.Pointer(&s[0])
+	memclrNoHeapPointers(ptr, size)
+}
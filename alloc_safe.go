@@ -0,0 +1,25 @@
+//go:build strings2_safe || asan || msan
+
+package strings2
+
+// This file replaces the //go:linkname calls into the runtime in
+// alloc_linkname.go with plain make and clear. It is selected with the
+// strings2_safe build tag, for toolchains or linkers that reject the
+// linkname references, and automatically under -asan and -msan, which
+// check every read of uninitialized memory.
+
+const safeAlloc = true
+
+// MakeNoZero returns a byte slice of length l. In this build the memory is
+// zeroed like any other allocation.
+func MakeNoZero(l int) []byte {
+	return make([]byte, l)
+}
+
+func MakeNoZeroCap(l int, c int) []byte {
+	return make([]byte, l, c)
+}
+
+func memclr[T any](s []T) {
+	clear(s)
+}
@@ -0,0 +1,41 @@
+package strings2
+
+import "testing"
+
+// These tests cover whichever allocation layer the build selected; run
+// them with and without -tags strings2_safe to cover both.
+
+func TestMakeNoZero(t *testing.T) {
+	t.Logf("safe allocation layer: %v", safeAlloc)
+	for _, n := range []int{0, 1, 7, 4096, 1 << 20} {
+		b := MakeNoZero(n)
+		if len(b) != n || cap(b) < n {
+			t.Fatalf("MakeNoZero(%d): len %d cap %d", n, len(b), cap(b))
+		}
+		for i := range b {
+			b[i] = byte(i)
+		}
+		c := MakeNoZeroCap(n/2, n)
+		if len(c) != n/2 || cap(c) < n {
+			t.Fatalf("MakeNoZeroCap(%d, %d): len %d cap %d", n/2, n, len(c), cap(c))
+		}
+		c = append(c[:0], b...)
+		if string(c) != string(b) {
+			t.Fatalf("MakeNoZeroCap(%d, %d) not writable", n/2, n)
+		}
+	}
+}
+
+func TestMemclr(t *testing.T) {
+	b := []byte("secret data")
+	memclr(b[2:])
+	if string(b) != "se\x00\x00\x00\x00\x00\x00\x00\x00\x00" {
+		t.Fatalf("memclr([]byte) = %q", b)
+	}
+	u := []uint64{1, 2, 3}
+	memclr(u)
+	if u[0]|u[1]|u[2] != 0 {
+		t.Fatalf("memclr([]uint64) = %v", u)
+	}
+	memclr([]byte(nil))
+}
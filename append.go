@@ -0,0 +1,215 @@
+package strings2
+
+import (
+	"math"
+	"math/bits"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/NikoMalik/strconv2"
+)
+
+// Append* variants write their result to the end of dst and return the
+// extended buffer, so output can be assembled in one caller-owned (for
+// example pooled) buffer without intermediate strings. When dst has to
+// grow it is reallocated once, without zeroing, using the same policy as
+// Builder.
+
+// growBytes returns dst with room for at least n more bytes.
+func growBytes(dst []byte, n int) []byte {
+	if cap(dst)-len(dst) >= n {
+		return dst
+	}
+	buf := MakeNoZero(2*cap(dst) + n)[:len(dst)]
+	copy(buf, dst)
+	return buf
+}
+
+// AppendToLower appends the lower-case mapping of s to dst.
+func AppendToLower(dst []byte, s string) []byte {
+	dst = growBytes(dst, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			return appendMapRunes(dst, s[i:], &lowerTable, unicode.ToLower)
+		}
+		dst = append(dst, lowerTable[c])
+	}
+	return dst
+}
+
+// AppendToUpper appends the upper-case mapping of s to dst.
+func AppendToUpper(dst []byte, s string) []byte {
+	dst = growBytes(dst, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			return appendMapRunes(dst, s[i:], &upperTable, unicode.ToUpper)
+		}
+		dst = append(dst, upperTable[c])
+	}
+	return dst
+}
+
+// appendMapRunes is the non-ASCII tail of AppendToLower and AppendToUpper;
+// like strings.Map it turns invalid UTF-8 into U+FFFD.
+func appendMapRunes(dst []byte, s string, table *[256]byte, mapping func(rune) rune) []byte {
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			dst = append(dst, table[c])
+			i++
+			continue
+		}
+		r, wid := utf8.DecodeRuneInString(s[i:])
+		dst = utf8.AppendRune(dst, mapping(r))
+		i += wid
+	}
+	return dst
+}
+
+// AppendReplace appends a copy of s with the first n non-overlapping
+// instances of old replaced by new to dst. If n < 0, there is no limit on
+// the number of replacements. It follows ReplaceString.
+func AppendReplace(dst []byte, s, old, new string, n int) []byte {
+	if n == 0 || old == new || len(s) == 0 {
+		return append(dst, s...)
+	}
+	if n < 0 {
+		n = 1 << 30
+	}
+	if len(old) == 0 {
+		return appendReplaceEmptyOld(dst, s, new, n)
+	}
+
+	sb := unsafeBytes(s)
+	oldb := unsafeBytes(old)
+	actualReplaces := countLimited(sb, oldb, len(old), n)
+	if actualReplaces == 0 {
+		return append(dst, s...)
+	}
+
+	dst = growBytes(dst, len(s)+actualReplaces*(len(new)-len(old)))
+	pos := 0
+	for i := 0; i < actualReplaces; i++ {
+		idx := findIndex(sb, oldb, len(old), pos) + pos
+		dst = append(dst, s[pos:idx]...)
+		dst = append(dst, new...)
+		pos = idx + len(old)
+	}
+	return append(dst, s[pos:]...)
+}
+
+// old == ""
+func appendReplaceEmptyOld(dst []byte, s, new string, n int) []byte {
+	n = min(n, utf8.RuneCountInString(s)+1)
+	dst = growBytes(dst, len(s)+n*len(new))
+
+	dst = append(dst, new...)
+	n--
+	start := 0
+	for n > 0 && start < len(s) {
+		_, wid := utf8.DecodeRuneInString(s[start:])
+		dst = append(dst, s[start:start+wid]...)
+		dst = append(dst, new...)
+		start += wid
+		n--
+	}
+	return append(dst, s[start:]...)
+}
+
+// AppendRepeat appends count copies of s to dst.
+//
+// It panics if count is negative or if the result of (len(s) * count)
+// overflows.
+func AppendRepeat(dst []byte, s string, count int) []byte {
+	if count < 0 {
+		panic("strings: negative Repeat count")
+	}
+	hi, lo := bits.Mul(uint(len(s)), uint(count))
+	if hi > 0 || lo > uint(math.MaxInt) {
+		panic("strings: Repeat output length overflow")
+	}
+	n := int(lo) // lo = len(s) * count
+	if n == 0 {
+		return dst
+	}
+
+	dst = growBytes(dst, n)
+	start := len(dst)
+	dst = append(dst, s...)
+	// Double the written region, capped by chunkLimit as in Repeat.
+	const chunkLimit = 8 * 1024
+	chunkMax := max(chunkLimit/len(s)*len(s), len(s))
+	for len(dst)-start < n {
+		chunk := min(n-(len(dst)-start), len(dst)-start, chunkMax)
+		dst = append(dst, dst[start:start+chunk]...)
+	}
+	return dst
+}
+
+// AppendToString appends the ToString form of arg to dst. Numbers,
+// booleans and times are formatted directly into dst.
+func AppendToString(dst []byte, arg any, timeFormat ...string) []byte {
+	switch v := arg.(type) {
+	case int:
+		return appendInt(dst, int64(v))
+	case int8:
+		return appendInt(dst, int64(v))
+	case int16:
+		return appendInt(dst, int64(v))
+	case int32:
+		return appendInt(dst, int64(v))
+	case int64:
+		return appendInt(dst, v)
+	case uint:
+		return appendUint(dst, uint64(v))
+	case uint8:
+		return appendUint(dst, uint64(v))
+	case uint16:
+		return appendUint(dst, uint64(v))
+	case uint32:
+		return appendUint(dst, uint64(v))
+	case uint64:
+		return appendUint(dst, v)
+	case string:
+		return append(dst, v...)
+	case []byte:
+		return append(dst, v...)
+	case bool:
+		return strconv.AppendBool(dst, v)
+	case float32:
+		return strconv.AppendFloat(dst, float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.AppendFloat(dst, v, 'f', -1, 64)
+	case time.Time:
+		if _, ok := lookupFormatter(arg); ok {
+			return append(dst, ToString(arg)...)
+		}
+		if len(timeFormat) > 0 {
+			return v.AppendFormat(dst, timeFormat[0])
+		}
+		return v.AppendFormat(dst, "2006-01-02 15:04:05")
+	case reflect.Value:
+		return AppendToString(dst, v.Interface(), timeFormat...)
+	default:
+		return append(dst, ToString(arg, timeFormat...)...)
+	}
+}
+
+// appendInt formats v with strconv2 straight into the spare capacity of
+// dst. strconv2 needs one byte past the digits, hence SAFETY_BUF_SIZE.
+func appendInt(dst []byte, v int64) []byte {
+	dst = growBytes(dst, strconv2.SAFETY_BUF_SIZE)
+	n := strconv2.FormatInt6410(dst[len(dst):len(dst)+strconv2.SAFETY_BUF_SIZE], v)
+	return dst[:len(dst)+n]
+}
+
+func appendUint(dst []byte, v uint64) []byte {
+	dst = growBytes(dst, strconv2.SAFETY_BUF_SIZE)
+	n := strconv2.FormatUint6410(dst[len(dst):len(dst)+strconv2.SAFETY_BUF_SIZE], v)
+	return dst[:len(dst)+n]
+}
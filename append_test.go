@@ -0,0 +1,126 @@
+package strings2
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendCase(t *testing.T) {
+	tests := []string{
+		"HELLO World",
+		"ПрИвЕт Мир",
+		"你好 世界",
+		"mixed ÅSCII and ascii",
+		"bad \xff utf8",
+		Repeat("ABCxyz", 500),
+	}
+	for _, s := range tests {
+		prefix := []byte("prefix:")
+		if got, want := string(AppendToLower(prefix, s)), "prefix:"+ToLower(s); got != want {
+			t.Errorf("AppendToLower(%q) = %q, want %q", s, got, want)
+		}
+		if got, want := string(AppendToUpper(nil, s)), ToUpper(s); got != want {
+			t.Errorf("AppendToUpper(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func TestAppendReplace(t *testing.T) {
+	tests := []struct {
+		s, old, new string
+		n           int
+	}{
+		{"hello hello world", "hello", "hi", -1},
+		{"hello hello world", "hello", "greetings", -1},
+		{"aaa", "a", "b", 2},
+		{"привет привет мир", "привет", "здравствуй", -1},
+		{"abc", "", "-", -1},
+		{"abc", "", "-", 2},
+		{"héllo", "", "-", -1},
+		{"anything", "old", "new", 0},
+		{"no match", "xyz", "1", -1},
+		{"", "a", "b", -1},
+		{"same", "a", "a", -1},
+	}
+	for _, tt := range tests {
+		got := string(AppendReplace([]byte(">"), tt.s, tt.old, tt.new, tt.n))
+		want := ">" + ReplaceString(tt.s, tt.old, tt.new, tt.n)
+		if got != want {
+			t.Errorf("AppendReplace(%q, %q, %q, %d) = %q, want %q", tt.s, tt.old, tt.new, tt.n, got, want)
+		}
+	}
+}
+
+func TestAppendRepeat(t *testing.T) {
+	tests := []struct {
+		s     string
+		count int
+	}{
+		{"a", 0},
+		{"a", 1},
+		{"ab", 7},
+		{"привет", 2000},
+		{"", 100},
+		{strings.Repeat("x", 5000), 3},
+	}
+	for _, tt := range tests {
+		got := string(AppendRepeat([]byte("#"), tt.s, tt.count))
+		if want := "#" + strings.Repeat(tt.s, tt.count); got != want {
+			t.Fatalf("AppendRepeat(%q, %d) mismatch", tt.s, tt.count)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic on overflow")
+		}
+	}()
+	AppendRepeat(nil, "abc", math.MaxInt)
+}
+
+func TestAppendToString(t *testing.T) {
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	x := 777
+	cases := []any{
+		int(42), int8(-5), int16(1234), int32(-999), int64(math.MinInt64),
+		uint(77), uint8(255), uint16(65000), uint32(1<<31 + 2), uint64(math.MaxUint64),
+		"abc", []byte("xyz"), true, float32(3.14), 2.5, tm, myStringer{}, &x, []int{1, 2, 3},
+	}
+	for _, v := range cases {
+		got := string(AppendToString([]byte("v="), v))
+		if want := "v=" + ToString(v); got != want {
+			t.Errorf("AppendToString(%#v) = %q, want %q", v, got, want)
+		}
+	}
+	if got, want := string(AppendToString(nil, tm, time.RFC3339)), tm.Format(time.RFC3339); got != want {
+		t.Errorf("AppendToString(time, RFC3339) = %q, want %q", got, want)
+	}
+}
+
+func TestAppendNoAlloc(t *testing.T) {
+	buf := make([]byte, 0, 1024)
+	allocs := testing.AllocsPerRun(100, func() {
+		b := buf[:0]
+		b = AppendToLower(b, "Content-Type")
+		b = append(b, ": "...)
+		b = AppendReplace(b, "text/html; charset=UTF-8", "UTF-8", "utf-8", -1)
+		b = AppendRepeat(b, "-", 10)
+		b = AppendToString(b, 123456)
+		b = AppendToString(b, 3.5)
+		_ = b
+	})
+	if allocs != 0 {
+		t.Fatalf("expected 0 allocs, got %v", allocs)
+	}
+}
+
+func BenchmarkAppendToLower(b *testing.B) {
+	s := strings.Repeat("Hello World! ", 100)
+	buf := make([]byte, 0, len(s))
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = AppendToLower(buf[:0], s)
+	}
+}
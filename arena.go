@@ -0,0 +1,89 @@
+package strings2
+
+// DefaultSlabSize is the slab size of a StringArena created with a size
+// <= 0, and of the zero StringArena.
+const DefaultSlabSize = 32 << 10
+
+// StringArena carves many small strings and byte slices out of large
+// slabs allocated with MakeNoZero, so request-scoped parsing makes a few
+// big allocations instead of thousands of small ones. Requests larger than
+// a quarter of a slab get an allocation of their own, so a slab is never
+// mostly wasted.
+//
+// Everything handed out stays valid as long as it is referenced; Reset
+// only stops the arena from handing out the rest of the current slab.
+// Keeping one small string alive keeps its whole slab alive.
+//
+// A StringArena is not safe for concurrent use.
+type StringArena struct {
+	slab     []byte // unused part of the current slab
+	slabSize int
+}
+
+// NewStringArena returns a StringArena that allocates slabs of slabSize
+// bytes, or DefaultSlabSize if slabSize <= 0.
+func NewStringArena(slabSize int) *StringArena {
+	if slabSize <= 0 {
+		slabSize = DefaultSlabSize
+	}
+	return &StringArena{slabSize: slabSize}
+}
+
+// Alloc returns a slice of length and capacity n from the arena. Its
+// contents are not zeroed. Appending to it reallocates rather than
+// overwriting later allocations.
+func (a *StringArena) Alloc(n int) []byte {
+	if n < 0 {
+		panic("strings2.StringArena.Alloc: negative size")
+	}
+	if n > len(a.slab) {
+		if a.slabSize == 0 {
+			a.slabSize = DefaultSlabSize
+		}
+		if n > a.slabSize/4 {
+			return MakeNoZero(n)
+		}
+		a.slab = MakeNoZero(a.slabSize)
+	}
+	b := a.slab[:n:n]
+	a.slab = a.slab[n:]
+	return b
+}
+
+// CopyString returns a copy of s allocated in the arena.
+func (a *StringArena) CopyString(s string) string {
+	if s == "" {
+		return ""
+	}
+	b := a.Alloc(len(s))
+	copy(b, s)
+	return unsafeString(b)
+}
+
+// CopyBytes returns a string holding a copy of b allocated in the arena.
+func (a *StringArena) CopyBytes(b []byte) string {
+	return a.CopyString(unsafeString(b))
+}
+
+// AppendConcat returns the concatenation of parts allocated in the arena
+// as one string.
+func (a *StringArena) AppendConcat(parts ...string) string {
+	n := 0
+	for _, p := range parts {
+		n += len(p)
+	}
+	if n == 0 {
+		return ""
+	}
+	b := a.Alloc(n)[:0]
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return unsafeString(b)
+}
+
+// Reset drops the current slab, so the next allocation starts a new one.
+// Strings and slices handed out before stay valid.
+func (a *StringArena) Reset() {
+	a.slab = nil
+}
@@ -0,0 +1,68 @@
+package strings2
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestStringArena(t *testing.T) {
+	a := NewStringArena(64)
+	var got []string
+	var want []string
+	for i := range 100 {
+		s := "key" + strconv.Itoa(i)
+		got = append(got, a.CopyString(s))
+		want = append(want, s)
+	}
+	cat := a.AppendConcat("a", "", "bc", "ж")
+	big := a.CopyString(strings.Repeat("x", 100)) // dedicated allocation
+	fromBytes := a.CopyBytes([]byte("bytes"))
+	b := a.Alloc(3)
+	copy(b, "xyz")
+	b = append(b, "!!!"...) // must not overwrite the next allocation
+	next := a.CopyString("next")
+	a.Reset()
+	after := a.CopyString("after reset")
+
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("string %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if cat != "abcж" || big != strings.Repeat("x", 100) || fromBytes != "bytes" ||
+		string(b) != "xyz!!!" || next != "next" || after != "after reset" {
+		t.Fatalf("unexpected %q %q %q %q %q", cat, fromBytes, b, next, after)
+	}
+	if a.CopyString("") != "" || a.AppendConcat() != "" || len(a.Alloc(0)) != 0 {
+		t.Fatal("empty allocations")
+	}
+
+	var zero StringArena
+	if zero.CopyString("zero") != "zero" {
+		t.Fatal("zero StringArena not usable")
+	}
+}
+
+func TestStringArenaAllocs(t *testing.T) {
+	a := NewStringArena(1 << 16)
+	parts := []string{"GET", " ", "/index.html"}
+	allocs := testing.AllocsPerRun(10, func() {
+		a.Reset()
+		for range 1000 {
+			a.CopyString("content-type")
+			a.AppendConcat(parts...)
+		}
+	})
+	if allocs != 1 {
+		t.Fatalf("expected 1 alloc per 1000 strings, got %v", allocs)
+	}
+}
+
+func BenchmarkStringArena(b *testing.B) {
+	a := NewStringArena(0)
+	b.ReportAllocs()
+	for b.Loop() {
+		a.CopyString("x-request-id")
+	}
+}
@@ -1,34 +1,91 @@
 package strings2
 
 import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
 	"unicode/utf8"
 	"unsafe"
+
+	"github.com/NikoMalik/strconv2"
+)
+
+var (
+	_ io.Writer       = (*Builder)(nil)
+	_ io.ByteWriter   = (*Builder)(nil)
+	_ io.StringWriter = (*Builder)(nil)
+	_ io.ReaderFrom   = (*Builder)(nil)
+	_ io.WriterTo     = (*Builder)(nil)
 )
 
+// A Builder is used to efficiently build a string using write methods.
+// Do not copy a non-zero Builder: unless the package is built with the
+// strings2_nocopycheck tag, every write path checks that the builder has
+// not been copied by value and panics if it has.
 type Builder struct {
-	noCopy noCopy
-	buf    []byte
+	noCopy  noCopy
+	addr    *Builder // of receiver, to detect copies by value
+	buf     []byte
+	growth  GrowthPolicy // nil: 2*cap+n
+	maxSize int          // 0: unlimited
 }
 
 // NewBuilder returns the new Builder with preallocated cap
 func NewBuilder(cap int) *Builder {
-	return &Builder{
-		buf: MakeNoZeroCap(0, cap),
-	}
+	b := &Builder{}
+	b.init(cap)
+	return b
+}
+
+// init gives b a buffer with capacity cap and records its address: b owns
+// memory before its first write, so copies of it must be detected from the
+// start. It is kept out of line so that NewBuilder stays within the
+// inlining budget and a Builder that does not escape stays on the stack.
+//
+//go:noinline
+func (b *Builder) init(cap int) {
+	b.buf = MakeNoZeroCap(0, cap)
+	b.addr = noescapeBuilder(b)
+}
+
+// noescapeBuilder hides b from escape analysis, like abi.NoEscape in
+// strings.Builder, so storing the self-pointer does not move a Builder
+// from the stack to the heap. The pointer only ever refers to b itself.
+func noescapeBuilder(b *Builder) *Builder {
+	var p *Builder
+	*(*uintptr)(unsafe.Pointer(&p)) = uintptr(unsafe.Pointer(b))
+	return p
 }
 
 // ResetAndKeepCap sets all data to zero and keep cap
 func (b *Builder) ResetAndKeepCap() {
+	b.copyCheck()
 	b.buf = b.buf[:cap(b.buf)]
 	memclr(b.buf)
 	b.buf = b.buf[:0]
 }
 
-// String returns the accumulated string.
+// String returns the accumulated string. The string shares memory with
+// the builder: appending writes leave it intact, but ResetAndKeepCap and
+// the in-place edits (Truncate, TrimRight, InsertString, Delete,
+// ReplaceRange and SetByte) modify that memory, changing every string
+// returned before them. Use Detach when the string must outlive them.
 func (b *Builder) String() string {
 	return unsafe.String(unsafe.SliceData(b.buf), len(b.buf))
 }
 
+// Detach returns the accumulated string and hands ownership of its bytes
+// to it, without copying. The builder is left empty with no buffer, so the
+// next write allocates a new one and neither ResetAndKeepCap nor a
+// BuilderPool can reuse the memory behind the returned string.
+func (b *Builder) Detach() string {
+	b.copyCheck()
+	s := unsafe.String(unsafe.SliceData(b.buf), len(b.buf))
+	b.buf = nil
+	return s
+}
+
 // Len returns the number of accumulated bytes; b.Len() == len(b.String()).
 func (b *Builder) Len() int { return len(b.buf) }
 
@@ -37,51 +94,305 @@ func (b *Builder) Len() int { return len(b.buf) }
 // already written.
 func (b *Builder) Cap() int { return cap(b.buf) }
 
+// Bytes returns the accumulated bytes. The slice aliases the builder's
+// buffer and is only valid until the next modification of the builder.
+func (b *Builder) Bytes() []byte { return b.buf }
+
+// Reader returns an [strings.Reader] over the accumulated string. It does
+// not copy; like String, it is only valid until the builder is reset.
+func (b *Builder) Reader() *strings.Reader { return strings.NewReader(b.String()) }
+
 // Reset resets the [Builder] to be empty.
 func (b *Builder) Reset() {
+	b.addr = nil
 	b.buf = nil
 }
 
-func (b *Builder) grow(n int) {
-	buf := MakeNoZero(2*cap(b.buf) + n)[:len(b.buf)]
-	copy(buf, b.buf)
-	b.buf = buf
-}
-
+// Grow grows b's capacity, if necessary, to guarantee space for another n
+// bytes, following the growth policy. It panics with ErrTooLarge if that
+// would exceed the maximum size.
 func (b *Builder) Grow(n int) {
+	b.copyCheck()
 	if n < 0 {
 		panic("strings.Builder.Grow: negative count")
 	}
-	if cap(b.buf)-len(b.buf) < n {
-		b.grow(n)
-	}
+	b.reserve(n)
 }
 
 // Write appends the contents of p to b's buffer.
-// Write always returns len(p), nil.
+// Write returns len(p), nil, or 0, ErrTooLarge without writing anything if
+// p does not fit within the maximum size.
 func (b *Builder) Write(p []byte) (int, error) {
+	b.copyCheck()
+	if err := b.ensure(len(p)); err != nil {
+		return 0, err
+	}
 	b.buf = append(b.buf, p...)
 	return len(p), nil
 }
 
 // WriteByte appends the byte c to b's buffer.
-// The returned error is always nil.
+// The returned error is nil, or ErrTooLarge if b is at its maximum size.
 func (b *Builder) WriteByte(c byte) error {
+	b.copyCheck()
+	if err := b.ensure(1); err != nil {
+		return err
+	}
 	b.buf = append(b.buf, c)
 	return nil
 }
 
 // WriteRune appends the UTF-8 encoding of Unicode code point r to b's buffer.
-// It returns the length of r and a nil error.
+// It returns the length of r and a nil error, or 0, ErrTooLarge without
+// writing anything if r does not fit within the maximum size.
 func (b *Builder) WriteRune(r rune) (int, error) {
+	b.copyCheck()
+	w := utf8.RuneLen(r)
+	if w < 0 {
+		w = 3 // encoded as U+FFFD
+	}
+	if err := b.ensure(w); err != nil {
+		return 0, err
+	}
 	n := len(b.buf)
 	b.buf = utf8.AppendRune(b.buf, r)
 	return len(b.buf) - n, nil
 }
 
 // WriteString appends the contents of s to b's buffer.
-// It returns the length of s and a nil error.
+// It returns the length of s and a nil error, or 0, ErrTooLarge without
+// writing anything if s does not fit within the maximum size.
 func (b *Builder) WriteString(s string) (int, error) {
+	b.copyCheck()
+	if err := b.ensure(len(s)); err != nil {
+		return 0, err
+	}
 	b.buf = append(b.buf, s...)
 	return len(s), nil
 }
+
+// Truncate discards all but the first n accumulated bytes, keeping the
+// capacity. It panics if n is negative or greater than b.Len().
+func (b *Builder) Truncate(n int) {
+	b.copyCheck()
+	if n < 0 || n > len(b.buf) {
+		panic("strings2.Builder.Truncate: truncation out of range")
+	}
+	b.buf = b.buf[:n]
+}
+
+// TrimRight removes all trailing Unicode code points contained in cutset,
+// as strings.TrimRight does.
+func (b *Builder) TrimRight(cutset string) {
+	b.copyCheck()
+	b.buf = b.buf[:len(strings.TrimRight(b.String(), cutset))]
+}
+
+// InsertString inserts s before the byte at index i, shifting the
+// following bytes up and growing the buffer if needed. It panics if i is
+// out of range. s must not alias the builder's own contents.
+func (b *Builder) InsertString(i int, s string) {
+	b.ReplaceRange(i, i, s)
+}
+
+// Delete removes the bytes in b.String()[i:j], shifting the following
+// bytes down. It panics if the range is invalid.
+func (b *Builder) Delete(i, j int) {
+	b.ReplaceRange(i, j, "")
+}
+
+// ReplaceRange replaces the bytes in b.String()[i:j] with s, moving the
+// following bytes only once and growing the buffer if needed. It panics if
+// the range is invalid. s must not alias the builder's own contents.
+func (b *Builder) ReplaceRange(i, j int, s string) {
+	b.copyCheck()
+	n := len(b.buf)
+	if i < 0 || j < i || j > n {
+		panic("strings2.Builder: range out of bounds")
+	}
+	d := len(s) - (j - i)
+	if d > 0 {
+		b.reserve(d)
+		b.buf = b.buf[:n+d]
+	}
+	copy(b.buf[j+d:], b.buf[j:n])
+	copy(b.buf[i:], s)
+	b.buf = b.buf[:n+d]
+}
+
+// SetByte overwrites the byte at index i with c. It panics if i is out of
+// range.
+func (b *Builder) SetByte(i int, c byte) {
+	b.copyCheck()
+	b.buf[i] = c
+}
+
+// minRead is the minimum free space ReadFrom offers to a Read call, as in
+// bytes.Buffer.
+const minRead = 512
+
+// ReadFrom reads data from r until EOF and appends it to b's buffer,
+// growing it as needed without zeroing the new memory. The return value
+// n is the number of bytes read. Any error except io.EOF encountered
+// during the read is also returned. If b reaches its maximum size before
+// r reports EOF, ReadFrom stops and returns ErrTooLarge.
+func (b *Builder) ReadFrom(r io.Reader) (n int64, err error) {
+	b.copyCheck()
+	for {
+		end := cap(b.buf)
+		if end-len(b.buf) < minRead {
+			want := minRead
+			if b.maxSize > 0 {
+				want = min(want, b.maxSize-len(b.buf))
+			}
+			if want > end-len(b.buf) {
+				if err := b.grow(want); err != nil {
+					return n, err
+				}
+			}
+			end = cap(b.buf)
+		}
+		if b.maxSize > 0 {
+			end = min(end, b.maxSize)
+		}
+		l := len(b.buf)
+		if l >= end {
+			// At the maximum size: only fail if r has more to give.
+			var probe [1]byte
+			for {
+				m, e := r.Read(probe[:])
+				if m > 0 {
+					return n, ErrTooLarge
+				}
+				if e == io.EOF {
+					return n, nil
+				}
+				if e != nil {
+					return n, e
+				}
+			}
+		}
+		m, e := r.Read(b.buf[l:end])
+		if m < 0 {
+			panic("strings2.Builder.ReadFrom: reader returned negative count from Read")
+		}
+		b.buf = b.buf[:l+m]
+		n += int64(m)
+		if e == io.EOF {
+			return n, nil
+		}
+		if e != nil {
+			return n, e
+		}
+	}
+}
+
+// WriteTo writes the accumulated bytes to w. Unlike bytes.Buffer, the
+// builder keeps its contents. The return value n is the number of bytes
+// written; any error encountered during the write is also returned.
+func (b *Builder) WriteTo(w io.Writer) (n int64, err error) {
+	if len(b.buf) == 0 {
+		return 0, nil
+	}
+	m, err := w.Write(b.buf)
+	if m > len(b.buf) {
+		panic("strings2.Builder.WriteTo: invalid Write count")
+	}
+	if err == nil && m != len(b.buf) {
+		err = io.ErrShortWrite
+	}
+	return int64(m), err
+}
+
+// The formatting methods below have no error result; like Grow, they
+// panic with ErrTooLarge when the output would exceed the maximum size.
+
+// WriteInt appends the decimal form of i to b's buffer.
+func (b *Builder) WriteInt(i int64) {
+	b.copyCheck()
+	var tmp [strconv2.SAFETY_BUF_SIZE]byte
+	b.writeFormatted(tmp[:strconv2.FormatInt6410(tmp[:], i)])
+}
+
+// WriteUint appends the decimal form of u to b's buffer.
+func (b *Builder) WriteUint(u uint64) {
+	b.copyCheck()
+	var tmp [strconv2.SAFETY_BUF_SIZE]byte
+	b.writeFormatted(tmp[:strconv2.FormatUint6410(tmp[:], u)])
+}
+
+// WriteHex appends the lower-case hexadecimal form of u, without a 0x
+// prefix, to b's buffer.
+func (b *Builder) WriteHex(u uint64) {
+	b.copyCheck()
+	var tmp [16]byte
+	b.writeFormatted(strconv.AppendUint(tmp[:0], u, 16))
+}
+
+// WriteFloat appends the string form of the floating-point number f, as
+// generated by strconv.FormatFloat with the same fmt, prec and bitSize, to
+// b's buffer.
+func (b *Builder) WriteFloat(f float64, fmt byte, prec, bitSize int) {
+	b.copyCheck()
+	b.presize(24)
+	b.appendFormatted(strconv.AppendFloat(b.buf, f, fmt, prec, bitSize))
+}
+
+// WriteBool appends "true" or "false" to b's buffer.
+func (b *Builder) WriteBool(v bool) {
+	b.copyCheck()
+	if v {
+		b.writeFormatted([]byte("true"))
+	} else {
+		b.writeFormatted([]byte("false"))
+	}
+}
+
+// WriteTime appends t formatted according to layout to b's buffer.
+func (b *Builder) WriteTime(t time.Time, layout string) {
+	b.copyCheck()
+	b.presize(len(layout) + 10)
+	b.appendFormatted(t.AppendFormat(b.buf, layout))
+}
+
+// WriteQuoted appends a double-quoted Go string literal representing s,
+// as generated by strconv.Quote, to b's buffer.
+func (b *Builder) WriteQuoted(s string) {
+	b.copyCheck()
+	b.presize(len(s) + 2)
+	b.appendFormatted(strconv.AppendQuote(b.buf, s))
+}
+
+// writeFormatted appends p, formatted on the stack by one of the Write*
+// methods without an error result, and panics with ErrTooLarge if it does
+// not fit within the maximum size.
+func (b *Builder) writeFormatted(p []byte) {
+	b.reserve(len(p))
+	b.buf = append(b.buf, p...)
+}
+
+// presize grows b by the growth policy for output of about n bytes, so
+// that appendFormatted rarely needs append to reallocate. It never fails;
+// the maximum size is checked on the actual result.
+func (b *Builder) presize(n int) {
+	if cap(b.buf)-len(b.buf) < n && (b.maxSize <= 0 || n <= b.maxSize-len(b.buf)) {
+		b.grow(n)
+	}
+}
+
+// appendFormatted takes buf, the result of a strconv-style append to
+// b.buf, as b's new contents, or panics with ErrTooLarge, leaving b
+// unchanged, if it exceeds the maximum size. If the append outgrew the
+// presized capacity, the buffer append allocated is replaced by one from
+// grow, so the growth policy stays in charge of b's capacity.
+func (b *Builder) appendFormatted(buf []byte) {
+	if b.maxSize > 0 && len(buf) > b.maxSize {
+		panic(ErrTooLarge)
+	}
+	if cap(buf) != cap(b.buf) {
+		out := buf[len(b.buf):]
+		b.grow(len(out)) //nolint:errcheck // the size was checked above
+		buf = append(b.buf, out...)
+	}
+	b.buf = buf
+}
@@ -0,0 +1,761 @@
+package strings2
+
+import "unicode"
+
+// Case mapping data derived from the Unicode Character Database:
+// SpecialCasing.txt for the multi-rune mappings, UnicodeData.txt for the
+// canonical combining classes and the Greek decompositions.
+
+// specialCase is a single rune that maps to a multi-rune string.
+type specialCase struct {
+	r  rune
+	to string
+}
+
+// specialUpper holds the unconditional full uppercase mappings of
+// SpecialCasing.txt that expand to more than one rune, sorted by rune.
+var specialUpper = [...]specialCase{
+	{0x00DF, "SS"},                 // LATIN SMALL LETTER SHARP S
+	{0x0149, "\u02BCN"},            // LATIN SMALL LETTER N PRECEDED BY APOSTROPHE
+	{0x01F0, "J\u030C"},            // LATIN SMALL LETTER J WITH CARON
+	{0x0390, "\u0399\u0308\u0301"}, // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND TONOS
+	{0x03B0, "\u03A5\u0308\u0301"}, // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND TONOS
+	{0x0587, "\u0535\u0552"},       // ARMENIAN SMALL LIGATURE ECH YIWN
+	{0x1E96, "H\u0331"},            // LATIN SMALL LETTER H WITH LINE BELOW
+	{0x1E97, "T\u0308"},            // LATIN SMALL LETTER T WITH DIAERESIS
+	{0x1E98, "W\u030A"},            // LATIN SMALL LETTER W WITH RING ABOVE
+	{0x1E99, "Y\u030A"},            // LATIN SMALL LETTER Y WITH RING ABOVE
+	{0x1E9A, "A\u02BE"},            // LATIN SMALL LETTER A WITH RIGHT HALF RING
+	{0x1F50, "\u03A5\u0313"},       // GREEK SMALL LETTER UPSILON WITH PSILI
+	{0x1F52, "\u03A5\u0313\u0300"}, // GREEK SMALL LETTER UPSILON WITH PSILI AND VARIA
+	{0x1F54, "\u03A5\u0313\u0301"}, // GREEK SMALL LETTER UPSILON WITH PSILI AND OXIA
+	{0x1F56, "\u03A5\u0313\u0342"}, // GREEK SMALL LETTER UPSILON WITH PSILI AND PERISPOMENI
+	{0x1F80, "\u1F08\u0399"},       // GREEK SMALL LETTER ALPHA WITH PSILI AND YPOGEGRAMMENI
+	{0x1F81, "\u1F09\u0399"},       // GREEK SMALL LETTER ALPHA WITH DASIA AND YPOGEGRAMMENI
+	{0x1F82, "\u1F0A\u0399"},       // GREEK SMALL LETTER ALPHA WITH PSILI AND VARIA AND YPOGEGRAMMENI
+	{0x1F83, "\u1F0B\u0399"},       // GREEK SMALL LETTER ALPHA WITH DASIA AND VARIA AND YPOGEGRAMMENI
+	{0x1F84, "\u1F0C\u0399"},       // GREEK SMALL LETTER ALPHA WITH PSILI AND OXIA AND YPOGEGRAMMENI
+	{0x1F85, "\u1F0D\u0399"},       // GREEK SMALL LETTER ALPHA WITH DASIA AND OXIA AND YPOGEGRAMMENI
+	{0x1F86, "\u1F0E\u0399"},       // GREEK SMALL LETTER ALPHA WITH PSILI AND PERISPOMENI AND YPOGEGRAMMENI
+	{0x1F87, "\u1F0F\u0399"},       // GREEK SMALL LETTER ALPHA WITH DASIA AND PERISPOMENI AND YPOGEGRAMMENI
+	{0x1F88, "\u1F08\u0399"},       // GREEK CAPITAL LETTER ALPHA WITH PSILI AND PROSGEGRAMMENI
+	{0x1F89, "\u1F09\u0399"},       // GREEK CAPITAL LETTER ALPHA WITH DASIA AND PROSGEGRAMMENI
+	{0x1F8A, "\u1F0A\u0399"},       // GREEK CAPITAL LETTER ALPHA WITH PSILI AND VARIA AND PROSGEGRAMMENI
+	{0x1F8B, "\u1F0B\u0399"},       // GREEK CAPITAL LETTER ALPHA WITH DASIA AND VARIA AND PROSGEGRAMMENI
+	{0x1F8C, "\u1F0C\u0399"},       // GREEK CAPITAL LETTER ALPHA WITH PSILI AND OXIA AND PROSGEGRAMMENI
+	{0x1F8D, "\u1F0D\u0399"},       // GREEK CAPITAL LETTER ALPHA WITH DASIA AND OXIA AND PROSGEGRAMMENI
+	{0x1F8E, "\u1F0E\u0399"},       // GREEK CAPITAL LETTER ALPHA WITH PSILI AND PERISPOMENI AND PROSGEGRAMMENI
+	{0x1F8F, "\u1F0F\u0399"},       // GREEK CAPITAL LETTER ALPHA WITH DASIA AND PERISPOMENI AND PROSGEGRAMMENI
+	{0x1F90, "\u1F28\u0399"},       // GREEK SMALL LETTER ETA WITH PSILI AND YPOGEGRAMMENI
+	{0x1F91, "\u1F29\u0399"},       // GREEK SMALL LETTER ETA WITH DASIA AND YPOGEGRAMMENI
+	{0x1F92, "\u1F2A\u0399"},       // GREEK SMALL LETTER ETA WITH PSILI AND VARIA AND YPOGEGRAMMENI
+	{0x1F93, "\u1F2B\u0399"},       // GREEK SMALL LETTER ETA WITH DASIA AND VARIA AND YPOGEGRAMMENI
+	{0x1F94, "\u1F2C\u0399"},       // GREEK SMALL LETTER ETA WITH PSILI AND OXIA AND YPOGEGRAMMENI
+	{0x1F95, "\u1F2D\u0399"},       // GREEK SMALL LETTER ETA WITH DASIA AND OXIA AND YPOGEGRAMMENI
+	{0x1F96, "\u1F2E\u0399"},       // GREEK SMALL LETTER ETA WITH PSILI AND PERISPOMENI AND YPOGEGRAMMENI
+	{0x1F97, "\u1F2F\u0399"},       // GREEK SMALL LETTER ETA WITH DASIA AND PERISPOMENI AND YPOGEGRAMMENI
+	{0x1F98, "\u1F28\u0399"},       // GREEK CAPITAL LETTER ETA WITH PSILI AND PROSGEGRAMMENI
+	{0x1F99, "\u1F29\u0399"},       // GREEK CAPITAL LETTER ETA WITH DASIA AND PROSGEGRAMMENI
+	{0x1F9A, "\u1F2A\u0399"},       // GREEK CAPITAL LETTER ETA WITH PSILI AND VARIA AND PROSGEGRAMMENI
+	{0x1F9B, "\u1F2B\u0399"},       // GREEK CAPITAL LETTER ETA WITH DASIA AND VARIA AND PROSGEGRAMMENI
+	{0x1F9C, "\u1F2C\u0399"},       // GREEK CAPITAL LETTER ETA WITH PSILI AND OXIA AND PROSGEGRAMMENI
+	{0x1F9D, "\u1F2D\u0399"},       // GREEK CAPITAL LETTER ETA WITH DASIA AND OXIA AND PROSGEGRAMMENI
+	{0x1F9E, "\u1F2E\u0399"},       // GREEK CAPITAL LETTER ETA WITH PSILI AND PERISPOMENI AND PROSGEGRAMMENI
+	{0x1F9F, "\u1F2F\u0399"},       // GREEK CAPITAL LETTER ETA WITH DASIA AND PERISPOMENI AND PROSGEGRAMMENI
+	{0x1FA0, "\u1F68\u0399"},       // GREEK SMALL LETTER OMEGA WITH PSILI AND YPOGEGRAMMENI
+	{0x1FA1, "\u1F69\u0399"},       // GREEK SMALL LETTER OMEGA WITH DASIA AND YPOGEGRAMMENI
+	{0x1FA2, "\u1F6A\u0399"},       // GREEK SMALL LETTER OMEGA WITH PSILI AND VARIA AND YPOGEGRAMMENI
+	{0x1FA3, "\u1F6B\u0399"},       // GREEK SMALL LETTER OMEGA WITH DASIA AND VARIA AND YPOGEGRAMMENI
+	{0x1FA4, "\u1F6C\u0399"},       // GREEK SMALL LETTER OMEGA WITH PSILI AND OXIA AND YPOGEGRAMMENI
+	{0x1FA5, "\u1F6D\u0399"},       // GREEK SMALL LETTER OMEGA WITH DASIA AND OXIA AND YPOGEGRAMMENI
+	{0x1FA6, "\u1F6E\u0399"},       // GREEK SMALL LETTER OMEGA WITH PSILI AND PERISPOMENI AND YPOGEGRAMMENI
+	{0x1FA7, "\u1F6F\u0399"},       // GREEK SMALL LETTER OMEGA WITH DASIA AND PERISPOMENI AND YPOGEGRAMMENI
+	{0x1FA8, "\u1F68\u0399"},       // GREEK CAPITAL LETTER OMEGA WITH PSILI AND PROSGEGRAMMENI
+	{0x1FA9, "\u1F69\u0399"},       // GREEK CAPITAL LETTER OMEGA WITH DASIA AND PROSGEGRAMMENI
+	{0x1FAA, "\u1F6A\u0399"},       // GREEK CAPITAL LETTER OMEGA WITH PSILI AND VARIA AND PROSGEGRAMMENI
+	{0x1FAB, "\u1F6B\u0399"},       // GREEK CAPITAL LETTER OMEGA WITH DASIA AND VARIA AND PROSGEGRAMMENI
+	{0x1FAC, "\u1F6C\u0399"},       // GREEK CAPITAL LETTER OMEGA WITH PSILI AND OXIA AND PROSGEGRAMMENI
+	{0x1FAD, "\u1F6D\u0399"},       // GREEK CAPITAL LETTER OMEGA WITH DASIA AND OXIA AND PROSGEGRAMMENI
+	{0x1FAE, "\u1F6E\u0399"},       // GREEK CAPITAL LETTER OMEGA WITH PSILI AND PERISPOMENI AND PROSGEGRAMMENI
+	{0x1FAF, "\u1F6F\u0399"},       // GREEK CAPITAL LETTER OMEGA WITH DASIA AND PERISPOMENI AND PROSGEGRAMMENI
+	{0x1FB2, "\u1FBA\u0399"},       // GREEK SMALL LETTER ALPHA WITH VARIA AND YPOGEGRAMMENI
+	{0x1FB3, "\u0391\u0399"},       // GREEK SMALL LETTER ALPHA WITH YPOGEGRAMMENI
+	{0x1FB4, "\u0386\u0399"},       // GREEK SMALL LETTER ALPHA WITH OXIA AND YPOGEGRAMMENI
+	{0x1FB6, "\u0391\u0342"},       // GREEK SMALL LETTER ALPHA WITH PERISPOMENI
+	{0x1FB7, "\u0391\u0342\u0399"}, // GREEK SMALL LETTER ALPHA WITH PERISPOMENI AND YPOGEGRAMMENI
+	{0x1FBC, "\u0391\u0399"},       // GREEK CAPITAL LETTER ALPHA WITH PROSGEGRAMMENI
+	{0x1FC2, "\u1FCA\u0399"},       // GREEK SMALL LETTER ETA WITH VARIA AND YPOGEGRAMMENI
+	{0x1FC3, "\u0397\u0399"},       // GREEK SMALL LETTER ETA WITH YPOGEGRAMMENI
+	{0x1FC4, "\u0389\u0399"},       // GREEK SMALL LETTER ETA WITH OXIA AND YPOGEGRAMMENI
+	{0x1FC6, "\u0397\u0342"},       // GREEK SMALL LETTER ETA WITH PERISPOMENI
+	{0x1FC7, "\u0397\u0342\u0399"}, // GREEK SMALL LETTER ETA WITH PERISPOMENI AND YPOGEGRAMMENI
+	{0x1FCC, "\u0397\u0399"},       // GREEK CAPITAL LETTER ETA WITH PROSGEGRAMMENI
+	{0x1FD2, "\u0399\u0308\u0300"}, // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND VARIA
+	{0x1FD3, "\u0399\u0308\u0301"}, // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND OXIA
+	{0x1FD6, "\u0399\u0342"},       // GREEK SMALL LETTER IOTA WITH PERISPOMENI
+	{0x1FD7, "\u0399\u0308\u0342"}, // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND PERISPOMENI
+	{0x1FE2, "\u03A5\u0308\u0300"}, // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND VARIA
+	{0x1FE3, "\u03A5\u0308\u0301"}, // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND OXIA
+	{0x1FE4, "\u03A1\u0313"},       // GREEK SMALL LETTER RHO WITH PSILI
+	{0x1FE6, "\u03A5\u0342"},       // GREEK SMALL LETTER UPSILON WITH PERISPOMENI
+	{0x1FE7, "\u03A5\u0308\u0342"}, // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND PERISPOMENI
+	{0x1FF2, "\u1FFA\u0399"},       // GREEK SMALL LETTER OMEGA WITH VARIA AND YPOGEGRAMMENI
+	{0x1FF3, "\u03A9\u0399"},       // GREEK SMALL LETTER OMEGA WITH YPOGEGRAMMENI
+	{0x1FF4, "\u038F\u0399"},       // GREEK SMALL LETTER OMEGA WITH OXIA AND YPOGEGRAMMENI
+	{0x1FF6, "\u03A9\u0342"},       // GREEK SMALL LETTER OMEGA WITH PERISPOMENI
+	{0x1FF7, "\u03A9\u0342\u0399"}, // GREEK SMALL LETTER OMEGA WITH PERISPOMENI AND YPOGEGRAMMENI
+	{0x1FFC, "\u03A9\u0399"},       // GREEK CAPITAL LETTER OMEGA WITH PROSGEGRAMMENI
+	{0xFB00, "FF"},                 // LATIN SMALL LIGATURE FF
+	{0xFB01, "FI"},                 // LATIN SMALL LIGATURE FI
+	{0xFB02, "FL"},                 // LATIN SMALL LIGATURE FL
+	{0xFB03, "FFI"},                // LATIN SMALL LIGATURE FFI
+	{0xFB04, "FFL"},                // LATIN SMALL LIGATURE FFL
+	{0xFB05, "ST"},                 // LATIN SMALL LIGATURE LONG S T
+	{0xFB06, "ST"},                 // LATIN SMALL LIGATURE ST
+	{0xFB13, "\u0544\u0546"},       // ARMENIAN SMALL LIGATURE MEN NOW
+	{0xFB14, "\u0544\u0535"},       // ARMENIAN SMALL LIGATURE MEN ECH
+	{0xFB15, "\u0544\u053B"},       // ARMENIAN SMALL LIGATURE MEN INI
+	{0xFB16, "\u054E\u0546"},       // ARMENIAN SMALL LIGATURE VEW NOW
+	{0xFB17, "\u0544\u053D"},       // ARMENIAN SMALL LIGATURE MEN XEH
+}
+
+// specialTitle holds the unconditional full titlecase mappings of
+// SpecialCasing.txt that expand to more than one rune, sorted by rune.
+var specialTitle = [...]specialCase{
+	{0x00DF, "Ss"},                 // LATIN SMALL LETTER SHARP S
+	{0x0149, "\u02BCN"},            // LATIN SMALL LETTER N PRECEDED BY APOSTROPHE
+	{0x01F0, "J\u030C"},            // LATIN SMALL LETTER J WITH CARON
+	{0x0390, "\u0399\u0308\u0301"}, // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND TONOS
+	{0x03B0, "\u03A5\u0308\u0301"}, // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND TONOS
+	{0x0587, "\u0535\u0582"},       // ARMENIAN SMALL LIGATURE ECH YIWN
+	{0x1E96, "H\u0331"},            // LATIN SMALL LETTER H WITH LINE BELOW
+	{0x1E97, "T\u0308"},            // LATIN SMALL LETTER T WITH DIAERESIS
+	{0x1E98, "W\u030A"},            // LATIN SMALL LETTER W WITH RING ABOVE
+	{0x1E99, "Y\u030A"},            // LATIN SMALL LETTER Y WITH RING ABOVE
+	{0x1E9A, "A\u02BE"},            // LATIN SMALL LETTER A WITH RIGHT HALF RING
+	{0x1F50, "\u03A5\u0313"},       // GREEK SMALL LETTER UPSILON WITH PSILI
+	{0x1F52, "\u03A5\u0313\u0300"}, // GREEK SMALL LETTER UPSILON WITH PSILI AND VARIA
+	{0x1F54, "\u03A5\u0313\u0301"}, // GREEK SMALL LETTER UPSILON WITH PSILI AND OXIA
+	{0x1F56, "\u03A5\u0313\u0342"}, // GREEK SMALL LETTER UPSILON WITH PSILI AND PERISPOMENI
+	{0x1FB2, "\u1FBA\u0345"},       // GREEK SMALL LETTER ALPHA WITH VARIA AND YPOGEGRAMMENI
+	{0x1FB4, "\u0386\u0345"},       // GREEK SMALL LETTER ALPHA WITH OXIA AND YPOGEGRAMMENI
+	{0x1FB6, "\u0391\u0342"},       // GREEK SMALL LETTER ALPHA WITH PERISPOMENI
+	{0x1FB7, "\u0391\u0342\u0345"}, // GREEK SMALL LETTER ALPHA WITH PERISPOMENI AND YPOGEGRAMMENI
+	{0x1FC2, "\u1FCA\u0345"},       // GREEK SMALL LETTER ETA WITH VARIA AND YPOGEGRAMMENI
+	{0x1FC4, "\u0389\u0345"},       // GREEK SMALL LETTER ETA WITH OXIA AND YPOGEGRAMMENI
+	{0x1FC6, "\u0397\u0342"},       // GREEK SMALL LETTER ETA WITH PERISPOMENI
+	{0x1FC7, "\u0397\u0342\u0345"}, // GREEK SMALL LETTER ETA WITH PERISPOMENI AND YPOGEGRAMMENI
+	{0x1FD2, "\u0399\u0308\u0300"}, // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND VARIA
+	{0x1FD3, "\u0399\u0308\u0301"}, // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND OXIA
+	{0x1FD6, "\u0399\u0342"},       // GREEK SMALL LETTER IOTA WITH PERISPOMENI
+	{0x1FD7, "\u0399\u0308\u0342"}, // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND PERISPOMENI
+	{0x1FE2, "\u03A5\u0308\u0300"}, // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND VARIA
+	{0x1FE3, "\u03A5\u0308\u0301"}, // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND OXIA
+	{0x1FE4, "\u03A1\u0313"},       // GREEK SMALL LETTER RHO WITH PSILI
+	{0x1FE6, "\u03A5\u0342"},       // GREEK SMALL LETTER UPSILON WITH PERISPOMENI
+	{0x1FE7, "\u03A5\u0308\u0342"}, // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND PERISPOMENI
+	{0x1FF2, "\u1FFA\u0345"},       // GREEK SMALL LETTER OMEGA WITH VARIA AND YPOGEGRAMMENI
+	{0x1FF4, "\u038F\u0345"},       // GREEK SMALL LETTER OMEGA WITH OXIA AND YPOGEGRAMMENI
+	{0x1FF6, "\u03A9\u0342"},       // GREEK SMALL LETTER OMEGA WITH PERISPOMENI
+	{0x1FF7, "\u03A9\u0342\u0345"}, // GREEK SMALL LETTER OMEGA WITH PERISPOMENI AND YPOGEGRAMMENI
+	{0xFB00, "Ff"},                 // LATIN SMALL LIGATURE FF
+	{0xFB01, "Fi"},                 // LATIN SMALL LIGATURE FI
+	{0xFB02, "Fl"},                 // LATIN SMALL LIGATURE FL
+	{0xFB03, "Ffi"},                // LATIN SMALL LIGATURE FFI
+	{0xFB04, "Ffl"},                // LATIN SMALL LIGATURE FFL
+	{0xFB05, "St"},                 // LATIN SMALL LIGATURE LONG S T
+	{0xFB06, "St"},                 // LATIN SMALL LIGATURE ST
+	{0xFB13, "\u0544\u0576"},       // ARMENIAN SMALL LIGATURE MEN NOW
+	{0xFB14, "\u0544\u0565"},       // ARMENIAN SMALL LIGATURE MEN ECH
+	{0xFB15, "\u0544\u056B"},       // ARMENIAN SMALL LIGATURE MEN INI
+	{0xFB16, "\u054E\u0576"},       // ARMENIAN SMALL LIGATURE VEW NOW
+	{0xFB17, "\u0544\u056D"},       // ARMENIAN SMALL LIGATURE MEN XEH
+}
+
+// cccAbove contains the characters with canonical combining class 230 (Above).
+var cccAbove = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x0300, 0x0314, 1},
+		{0x033d, 0x0344, 1},
+		{0x0346, 0x0346, 1},
+		{0x034a, 0x034c, 1},
+		{0x0350, 0x0352, 1},
+		{0x0357, 0x0357, 1},
+		{0x035b, 0x035b, 1},
+		{0x0363, 0x036f, 1},
+		{0x0483, 0x0487, 1},
+		{0x0592, 0x0595, 1},
+		{0x0597, 0x0599, 1},
+		{0x059c, 0x05a1, 1},
+		{0x05a8, 0x05a9, 1},
+		{0x05ab, 0x05ac, 1},
+		{0x05af, 0x05af, 1},
+		{0x05c4, 0x05c4, 1},
+		{0x0610, 0x0617, 1},
+		{0x0653, 0x0654, 1},
+		{0x0657, 0x065b, 1},
+		{0x065d, 0x065e, 1},
+		{0x06d6, 0x06dc, 1},
+		{0x06df, 0x06e2, 1},
+		{0x06e4, 0x06e4, 1},
+		{0x06e7, 0x06e8, 1},
+		{0x06eb, 0x06ec, 1},
+		{0x0730, 0x0730, 1},
+		{0x0732, 0x0733, 1},
+		{0x0735, 0x0736, 1},
+		{0x073a, 0x073a, 1},
+		{0x073d, 0x073d, 1},
+		{0x073f, 0x0741, 1},
+		{0x0743, 0x0743, 1},
+		{0x0745, 0x0745, 1},
+		{0x0747, 0x0747, 1},
+		{0x0749, 0x074a, 1},
+		{0x07eb, 0x07f1, 1},
+		{0x07f3, 0x07f3, 1},
+		{0x0816, 0x0819, 1},
+		{0x081b, 0x0823, 1},
+		{0x0825, 0x0827, 1},
+		{0x0829, 0x082d, 1},
+		{0x0898, 0x0898, 1},
+		{0x089c, 0x089f, 1},
+		{0x08ca, 0x08ce, 1},
+		{0x08d4, 0x08e1, 1},
+		{0x08e4, 0x08e5, 1},
+		{0x08e7, 0x08e8, 1},
+		{0x08ea, 0x08ec, 1},
+		{0x08f3, 0x08f5, 1},
+		{0x08f7, 0x08f8, 1},
+		{0x08fb, 0x08ff, 1},
+		{0x0951, 0x0951, 1},
+		{0x0953, 0x0954, 1},
+		{0x09fe, 0x09fe, 1},
+		{0x0f82, 0x0f83, 1},
+		{0x0f86, 0x0f87, 1},
+		{0x135d, 0x135f, 1},
+		{0x17dd, 0x17dd, 1},
+		{0x193a, 0x193a, 1},
+		{0x1a17, 0x1a17, 1},
+		{0x1a75, 0x1a7c, 1},
+		{0x1ab0, 0x1ab4, 1},
+		{0x1abb, 0x1abc, 1},
+		{0x1ac1, 0x1ac2, 1},
+		{0x1ac5, 0x1ac9, 1},
+		{0x1acb, 0x1ace, 1},
+		{0x1b6b, 0x1b6b, 1},
+		{0x1b6d, 0x1b73, 1},
+		{0x1cd0, 0x1cd2, 1},
+		{0x1cda, 0x1cdb, 1},
+		{0x1ce0, 0x1ce0, 1},
+		{0x1cf4, 0x1cf4, 1},
+		{0x1cf8, 0x1cf9, 1},
+		{0x1dc0, 0x1dc1, 1},
+		{0x1dc3, 0x1dc9, 1},
+		{0x1dcb, 0x1dcc, 1},
+		{0x1dd1, 0x1df5, 1},
+		{0x1dfb, 0x1dfb, 1},
+		{0x1dfe, 0x1dfe, 1},
+		{0x20d0, 0x20d1, 1},
+		{0x20d4, 0x20d7, 1},
+		{0x20db, 0x20dc, 1},
+		{0x20e1, 0x20e1, 1},
+		{0x20e7, 0x20e7, 1},
+		{0x20e9, 0x20e9, 1},
+		{0x20f0, 0x20f0, 1},
+		{0x2cef, 0x2cf1, 1},
+		{0x2de0, 0x2dff, 1},
+		{0xa66f, 0xa66f, 1},
+		{0xa674, 0xa67d, 1},
+		{0xa69e, 0xa69f, 1},
+		{0xa6f0, 0xa6f1, 1},
+		{0xa8e0, 0xa8f1, 1},
+		{0xaab0, 0xaab0, 1},
+		{0xaab2, 0xaab3, 1},
+		{0xaab7, 0xaab8, 1},
+		{0xaabe, 0xaabf, 1},
+		{0xaac1, 0xaac1, 1},
+		{0xfe20, 0xfe26, 1},
+		{0xfe2e, 0xfe2f, 1},
+	},
+	R32: []unicode.Range32{
+		{0x10376, 0x1037a, 1},
+		{0x10a0f, 0x10a0f, 1},
+		{0x10a38, 0x10a38, 1},
+		{0x10ae5, 0x10ae5, 1},
+		{0x10d24, 0x10d27, 1},
+		{0x10eab, 0x10eac, 1},
+		{0x10f48, 0x10f4a, 1},
+		{0x10f4c, 0x10f4c, 1},
+		{0x10f82, 0x10f82, 1},
+		{0x10f84, 0x10f84, 1},
+		{0x11100, 0x11102, 1},
+		{0x11366, 0x1136c, 1},
+		{0x11370, 0x11374, 1},
+		{0x1145e, 0x1145e, 1},
+		{0x16b30, 0x16b36, 1},
+		{0x1d185, 0x1d189, 1},
+		{0x1d1aa, 0x1d1ad, 1},
+		{0x1d242, 0x1d244, 1},
+		{0x1e000, 0x1e006, 1},
+		{0x1e008, 0x1e018, 1},
+		{0x1e01b, 0x1e021, 1},
+		{0x1e023, 0x1e024, 1},
+		{0x1e026, 0x1e02a, 1},
+		{0x1e130, 0x1e136, 1},
+		{0x1e2ae, 0x1e2ae, 1},
+		{0x1e2ec, 0x1e2ef, 1},
+		{0x1e944, 0x1e949, 1},
+	},
+}
+
+// cccNonZero contains the characters with a non-zero canonical combining class.
+var cccNonZero = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x0300, 0x034e, 1},
+		{0x0350, 0x036f, 1},
+		{0x0483, 0x0487, 1},
+		{0x0591, 0x05bd, 1},
+		{0x05bf, 0x05bf, 1},
+		{0x05c1, 0x05c2, 1},
+		{0x05c4, 0x05c5, 1},
+		{0x05c7, 0x05c7, 1},
+		{0x0610, 0x061a, 1},
+		{0x064b, 0x065f, 1},
+		{0x0670, 0x0670, 1},
+		{0x06d6, 0x06dc, 1},
+		{0x06df, 0x06e4, 1},
+		{0x06e7, 0x06e8, 1},
+		{0x06ea, 0x06ed, 1},
+		{0x0711, 0x0711, 1},
+		{0x0730, 0x074a, 1},
+		{0x07eb, 0x07f3, 1},
+		{0x07fd, 0x07fd, 1},
+		{0x0816, 0x0819, 1},
+		{0x081b, 0x0823, 1},
+		{0x0825, 0x0827, 1},
+		{0x0829, 0x082d, 1},
+		{0x0859, 0x085b, 1},
+		{0x0898, 0x089f, 1},
+		{0x08ca, 0x08e1, 1},
+		{0x08e3, 0x08ff, 1},
+		{0x093c, 0x093c, 1},
+		{0x094d, 0x094d, 1},
+		{0x0951, 0x0954, 1},
+		{0x09bc, 0x09bc, 1},
+		{0x09cd, 0x09cd, 1},
+		{0x09fe, 0x09fe, 1},
+		{0x0a3c, 0x0a3c, 1},
+		{0x0a4d, 0x0a4d, 1},
+		{0x0abc, 0x0abc, 1},
+		{0x0acd, 0x0acd, 1},
+		{0x0b3c, 0x0b3c, 1},
+		{0x0b4d, 0x0b4d, 1},
+		{0x0bcd, 0x0bcd, 1},
+		{0x0c3c, 0x0c3c, 1},
+		{0x0c4d, 0x0c4d, 1},
+		{0x0c55, 0x0c56, 1},
+		{0x0cbc, 0x0cbc, 1},
+		{0x0ccd, 0x0ccd, 1},
+		{0x0d3b, 0x0d3c, 1},
+		{0x0d4d, 0x0d4d, 1},
+		{0x0dca, 0x0dca, 1},
+		{0x0e38, 0x0e3a, 1},
+		{0x0e48, 0x0e4b, 1},
+		{0x0eb8, 0x0eba, 1},
+		{0x0ec8, 0x0ecb, 1},
+		{0x0f18, 0x0f19, 1},
+		{0x0f35, 0x0f35, 1},
+		{0x0f37, 0x0f37, 1},
+		{0x0f39, 0x0f39, 1},
+		{0x0f71, 0x0f72, 1},
+		{0x0f74, 0x0f74, 1},
+		{0x0f7a, 0x0f7d, 1},
+		{0x0f80, 0x0f80, 1},
+		{0x0f82, 0x0f84, 1},
+		{0x0f86, 0x0f87, 1},
+		{0x0fc6, 0x0fc6, 1},
+		{0x1037, 0x1037, 1},
+		{0x1039, 0x103a, 1},
+		{0x108d, 0x108d, 1},
+		{0x135d, 0x135f, 1},
+		{0x1714, 0x1715, 1},
+		{0x1734, 0x1734, 1},
+		{0x17d2, 0x17d2, 1},
+		{0x17dd, 0x17dd, 1},
+		{0x18a9, 0x18a9, 1},
+		{0x1939, 0x193b, 1},
+		{0x1a17, 0x1a18, 1},
+		{0x1a60, 0x1a60, 1},
+		{0x1a75, 0x1a7c, 1},
+		{0x1a7f, 0x1a7f, 1},
+		{0x1ab0, 0x1abd, 1},
+		{0x1abf, 0x1ace, 1},
+		{0x1b34, 0x1b34, 1},
+		{0x1b44, 0x1b44, 1},
+		{0x1b6b, 0x1b73, 1},
+		{0x1baa, 0x1bab, 1},
+		{0x1be6, 0x1be6, 1},
+		{0x1bf2, 0x1bf3, 1},
+		{0x1c37, 0x1c37, 1},
+		{0x1cd0, 0x1cd2, 1},
+		{0x1cd4, 0x1ce0, 1},
+		{0x1ce2, 0x1ce8, 1},
+		{0x1ced, 0x1ced, 1},
+		{0x1cf4, 0x1cf4, 1},
+		{0x1cf8, 0x1cf9, 1},
+		{0x1dc0, 0x1dff, 1},
+		{0x20d0, 0x20dc, 1},
+		{0x20e1, 0x20e1, 1},
+		{0x20e5, 0x20f0, 1},
+		{0x2cef, 0x2cf1, 1},
+		{0x2d7f, 0x2d7f, 1},
+		{0x2de0, 0x2dff, 1},
+		{0x302a, 0x302f, 1},
+		{0x3099, 0x309a, 1},
+		{0xa66f, 0xa66f, 1},
+		{0xa674, 0xa67d, 1},
+		{0xa69e, 0xa69f, 1},
+		{0xa6f0, 0xa6f1, 1},
+		{0xa806, 0xa806, 1},
+		{0xa82c, 0xa82c, 1},
+		{0xa8c4, 0xa8c4, 1},
+		{0xa8e0, 0xa8f1, 1},
+		{0xa92b, 0xa92d, 1},
+		{0xa953, 0xa953, 1},
+		{0xa9b3, 0xa9b3, 1},
+		{0xa9c0, 0xa9c0, 1},
+		{0xaab0, 0xaab0, 1},
+		{0xaab2, 0xaab4, 1},
+		{0xaab7, 0xaab8, 1},
+		{0xaabe, 0xaabf, 1},
+		{0xaac1, 0xaac1, 1},
+		{0xaaf6, 0xaaf6, 1},
+		{0xabed, 0xabed, 1},
+		{0xfb1e, 0xfb1e, 1},
+		{0xfe20, 0xfe2f, 1},
+	},
+	R32: []unicode.Range32{
+		{0x101fd, 0x101fd, 1},
+		{0x102e0, 0x102e0, 1},
+		{0x10376, 0x1037a, 1},
+		{0x10a0d, 0x10a0d, 1},
+		{0x10a0f, 0x10a0f, 1},
+		{0x10a38, 0x10a3a, 1},
+		{0x10a3f, 0x10a3f, 1},
+		{0x10ae5, 0x10ae6, 1},
+		{0x10d24, 0x10d27, 1},
+		{0x10eab, 0x10eac, 1},
+		{0x10f46, 0x10f50, 1},
+		{0x10f82, 0x10f85, 1},
+		{0x11046, 0x11046, 1},
+		{0x11070, 0x11070, 1},
+		{0x1107f, 0x1107f, 1},
+		{0x110b9, 0x110ba, 1},
+		{0x11100, 0x11102, 1},
+		{0x11133, 0x11134, 1},
+		{0x11173, 0x11173, 1},
+		{0x111c0, 0x111c0, 1},
+		{0x111ca, 0x111ca, 1},
+		{0x11235, 0x11236, 1},
+		{0x112e9, 0x112ea, 1},
+		{0x1133b, 0x1133c, 1},
+		{0x1134d, 0x1134d, 1},
+		{0x11366, 0x1136c, 1},
+		{0x11370, 0x11374, 1},
+		{0x11442, 0x11442, 1},
+		{0x11446, 0x11446, 1},
+		{0x1145e, 0x1145e, 1},
+		{0x114c2, 0x114c3, 1},
+		{0x115bf, 0x115c0, 1},
+		{0x1163f, 0x1163f, 1},
+		{0x116b6, 0x116b7, 1},
+		{0x1172b, 0x1172b, 1},
+		{0x11839, 0x1183a, 1},
+		{0x1193d, 0x1193e, 1},
+		{0x11943, 0x11943, 1},
+		{0x119e0, 0x119e0, 1},
+		{0x11a34, 0x11a34, 1},
+		{0x11a47, 0x11a47, 1},
+		{0x11a99, 0x11a99, 1},
+		{0x11c3f, 0x11c3f, 1},
+		{0x11d42, 0x11d42, 1},
+		{0x11d44, 0x11d45, 1},
+		{0x11d97, 0x11d97, 1},
+		{0x16af0, 0x16af4, 1},
+		{0x16b30, 0x16b36, 1},
+		{0x16ff0, 0x16ff1, 1},
+		{0x1bc9e, 0x1bc9e, 1},
+		{0x1d165, 0x1d169, 1},
+		{0x1d16d, 0x1d172, 1},
+		{0x1d17b, 0x1d182, 1},
+		{0x1d185, 0x1d18b, 1},
+		{0x1d1aa, 0x1d1ad, 1},
+		{0x1d242, 0x1d244, 1},
+		{0x1e000, 0x1e006, 1},
+		{0x1e008, 0x1e018, 1},
+		{0x1e01b, 0x1e021, 1},
+		{0x1e023, 0x1e024, 1},
+		{0x1e026, 0x1e02a, 1},
+		{0x1e130, 0x1e136, 1},
+		{0x1e2ae, 0x1e2ae, 1},
+		{0x1e2ec, 0x1e2ef, 1},
+		{0x1e8d0, 0x1e8d6, 1},
+		{0x1e944, 0x1e94a, 1},
+	},
+}
+
+// greekCase describes how the el locale uppercases an accented Greek letter.
+type greekCase struct {
+	r     rune
+	base  rune // uppercase letter without accents or breathings
+	flags uint8
+}
+
+const (
+	greekDialytika uint8 = 1 << iota // keep the diaeresis
+	greekIota                        // ypogegrammeni becomes a capital iota
+)
+
+// greekUpper maps accented Greek letters to their uppercase base letter
+// for the el locale, which drops accents and breathings when uppercasing
+// but keeps the dialytika and turns the ypogegrammeni into a capital iota.
+var greekUpper = [...]greekCase{
+	{0x0386, 0x0391, 0},              // GREEK CAPITAL LETTER ALPHA WITH TONOS
+	{0x0388, 0x0395, 0},              // GREEK CAPITAL LETTER EPSILON WITH TONOS
+	{0x0389, 0x0397, 0},              // GREEK CAPITAL LETTER ETA WITH TONOS
+	{0x038A, 0x0399, 0},              // GREEK CAPITAL LETTER IOTA WITH TONOS
+	{0x038C, 0x039F, 0},              // GREEK CAPITAL LETTER OMICRON WITH TONOS
+	{0x038E, 0x03A5, 0},              // GREEK CAPITAL LETTER UPSILON WITH TONOS
+	{0x038F, 0x03A9, 0},              // GREEK CAPITAL LETTER OMEGA WITH TONOS
+	{0x0390, 0x0399, greekDialytika}, // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND TONOS
+	{0x03AA, 0x0399, greekDialytika}, // GREEK CAPITAL LETTER IOTA WITH DIALYTIKA
+	{0x03AB, 0x03A5, greekDialytika}, // GREEK CAPITAL LETTER UPSILON WITH DIALYTIKA
+	{0x03AC, 0x0391, 0},              // GREEK SMALL LETTER ALPHA WITH TONOS
+	{0x03AD, 0x0395, 0},              // GREEK SMALL LETTER EPSILON WITH TONOS
+	{0x03AE, 0x0397, 0},              // GREEK SMALL LETTER ETA WITH TONOS
+	{0x03AF, 0x0399, 0},              // GREEK SMALL LETTER IOTA WITH TONOS
+	{0x03B0, 0x03A5, greekDialytika}, // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND TONOS
+	{0x03CA, 0x0399, greekDialytika}, // GREEK SMALL LETTER IOTA WITH DIALYTIKA
+	{0x03CB, 0x03A5, greekDialytika}, // GREEK SMALL LETTER UPSILON WITH DIALYTIKA
+	{0x03CC, 0x039F, 0},              // GREEK SMALL LETTER OMICRON WITH TONOS
+	{0x03CD, 0x03A5, 0},              // GREEK SMALL LETTER UPSILON WITH TONOS
+	{0x03CE, 0x03A9, 0},              // GREEK SMALL LETTER OMEGA WITH TONOS
+	{0x03D3, 0x03D2, 0},              // GREEK UPSILON WITH ACUTE AND HOOK SYMBOL
+	{0x03D4, 0x03D2, greekDialytika}, // GREEK UPSILON WITH DIAERESIS AND HOOK SYMBOL
+	{0x1F00, 0x0391, 0},              // GREEK SMALL LETTER ALPHA WITH PSILI
+	{0x1F01, 0x0391, 0},              // GREEK SMALL LETTER ALPHA WITH DASIA
+	{0x1F02, 0x0391, 0},              // GREEK SMALL LETTER ALPHA WITH PSILI AND VARIA
+	{0x1F03, 0x0391, 0},              // GREEK SMALL LETTER ALPHA WITH DASIA AND VARIA
+	{0x1F04, 0x0391, 0},              // GREEK SMALL LETTER ALPHA WITH PSILI AND OXIA
+	{0x1F05, 0x0391, 0},              // GREEK SMALL LETTER ALPHA WITH DASIA AND OXIA
+	{0x1F06, 0x0391, 0},              // GREEK SMALL LETTER ALPHA WITH PSILI AND PERISPOMENI
+	{0x1F07, 0x0391, 0},              // GREEK SMALL LETTER ALPHA WITH DASIA AND PERISPOMENI
+	{0x1F08, 0x0391, 0},              // GREEK CAPITAL LETTER ALPHA WITH PSILI
+	{0x1F09, 0x0391, 0},              // GREEK CAPITAL LETTER ALPHA WITH DASIA
+	{0x1F0A, 0x0391, 0},              // GREEK CAPITAL LETTER ALPHA WITH PSILI AND VARIA
+	{0x1F0B, 0x0391, 0},              // GREEK CAPITAL LETTER ALPHA WITH DASIA AND VARIA
+	{0x1F0C, 0x0391, 0},              // GREEK CAPITAL LETTER ALPHA WITH PSILI AND OXIA
+	{0x1F0D, 0x0391, 0},              // GREEK CAPITAL LETTER ALPHA WITH DASIA AND OXIA
+	{0x1F0E, 0x0391, 0},              // GREEK CAPITAL LETTER ALPHA WITH PSILI AND PERISPOMENI
+	{0x1F0F, 0x0391, 0},              // GREEK CAPITAL LETTER ALPHA WITH DASIA AND PERISPOMENI
+	{0x1F10, 0x0395, 0},              // GREEK SMALL LETTER EPSILON WITH PSILI
+	{0x1F11, 0x0395, 0},              // GREEK SMALL LETTER EPSILON WITH DASIA
+	{0x1F12, 0x0395, 0},              // GREEK SMALL LETTER EPSILON WITH PSILI AND VARIA
+	{0x1F13, 0x0395, 0},              // GREEK SMALL LETTER EPSILON WITH DASIA AND VARIA
+	{0x1F14, 0x0395, 0},              // GREEK SMALL LETTER EPSILON WITH PSILI AND OXIA
+	{0x1F15, 0x0395, 0},              // GREEK SMALL LETTER EPSILON WITH DASIA AND OXIA
+	{0x1F18, 0x0395, 0},              // GREEK CAPITAL LETTER EPSILON WITH PSILI
+	{0x1F19, 0x0395, 0},              // GREEK CAPITAL LETTER EPSILON WITH DASIA
+	{0x1F1A, 0x0395, 0},              // GREEK CAPITAL LETTER EPSILON WITH PSILI AND VARIA
+	{0x1F1B, 0x0395, 0},              // GREEK CAPITAL LETTER EPSILON WITH DASIA AND VARIA
+	{0x1F1C, 0x0395, 0},              // GREEK CAPITAL LETTER EPSILON WITH PSILI AND OXIA
+	{0x1F1D, 0x0395, 0},              // GREEK CAPITAL LETTER EPSILON WITH DASIA AND OXIA
+	{0x1F20, 0x0397, 0},              // GREEK SMALL LETTER ETA WITH PSILI
+	{0x1F21, 0x0397, 0},              // GREEK SMALL LETTER ETA WITH DASIA
+	{0x1F22, 0x0397, 0},              // GREEK SMALL LETTER ETA WITH PSILI AND VARIA
+	{0x1F23, 0x0397, 0},              // GREEK SMALL LETTER ETA WITH DASIA AND VARIA
+	{0x1F24, 0x0397, 0},              // GREEK SMALL LETTER ETA WITH PSILI AND OXIA
+	{0x1F25, 0x0397, 0},              // GREEK SMALL LETTER ETA WITH DASIA AND OXIA
+	{0x1F26, 0x0397, 0},              // GREEK SMALL LETTER ETA WITH PSILI AND PERISPOMENI
+	{0x1F27, 0x0397, 0},              // GREEK SMALL LETTER ETA WITH DASIA AND PERISPOMENI
+	{0x1F28, 0x0397, 0},              // GREEK CAPITAL LETTER ETA WITH PSILI
+	{0x1F29, 0x0397, 0},              // GREEK CAPITAL LETTER ETA WITH DASIA
+	{0x1F2A, 0x0397, 0},              // GREEK CAPITAL LETTER ETA WITH PSILI AND VARIA
+	{0x1F2B, 0x0397, 0},              // GREEK CAPITAL LETTER ETA WITH DASIA AND VARIA
+	{0x1F2C, 0x0397, 0},              // GREEK CAPITAL LETTER ETA WITH PSILI AND OXIA
+	{0x1F2D, 0x0397, 0},              // GREEK CAPITAL LETTER ETA WITH DASIA AND OXIA
+	{0x1F2E, 0x0397, 0},              // GREEK CAPITAL LETTER ETA WITH PSILI AND PERISPOMENI
+	{0x1F2F, 0x0397, 0},              // GREEK CAPITAL LETTER ETA WITH DASIA AND PERISPOMENI
+	{0x1F30, 0x0399, 0},              // GREEK SMALL LETTER IOTA WITH PSILI
+	{0x1F31, 0x0399, 0},              // GREEK SMALL LETTER IOTA WITH DASIA
+	{0x1F32, 0x0399, 0},              // GREEK SMALL LETTER IOTA WITH PSILI AND VARIA
+	{0x1F33, 0x0399, 0},              // GREEK SMALL LETTER IOTA WITH DASIA AND VARIA
+	{0x1F34, 0x0399, 0},              // GREEK SMALL LETTER IOTA WITH PSILI AND OXIA
+	{0x1F35, 0x0399, 0},              // GREEK SMALL LETTER IOTA WITH DASIA AND OXIA
+	{0x1F36, 0x0399, 0},              // GREEK SMALL LETTER IOTA WITH PSILI AND PERISPOMENI
+	{0x1F37, 0x0399, 0},              // GREEK SMALL LETTER IOTA WITH DASIA AND PERISPOMENI
+	{0x1F38, 0x0399, 0},              // GREEK CAPITAL LETTER IOTA WITH PSILI
+	{0x1F39, 0x0399, 0},              // GREEK CAPITAL LETTER IOTA WITH DASIA
+	{0x1F3A, 0x0399, 0},              // GREEK CAPITAL LETTER IOTA WITH PSILI AND VARIA
+	{0x1F3B, 0x0399, 0},              // GREEK CAPITAL LETTER IOTA WITH DASIA AND VARIA
+	{0x1F3C, 0x0399, 0},              // GREEK CAPITAL LETTER IOTA WITH PSILI AND OXIA
+	{0x1F3D, 0x0399, 0},              // GREEK CAPITAL LETTER IOTA WITH DASIA AND OXIA
+	{0x1F3E, 0x0399, 0},              // GREEK CAPITAL LETTER IOTA WITH PSILI AND PERISPOMENI
+	{0x1F3F, 0x0399, 0},              // GREEK CAPITAL LETTER IOTA WITH DASIA AND PERISPOMENI
+	{0x1F40, 0x039F, 0},              // GREEK SMALL LETTER OMICRON WITH PSILI
+	{0x1F41, 0x039F, 0},              // GREEK SMALL LETTER OMICRON WITH DASIA
+	{0x1F42, 0x039F, 0},              // GREEK SMALL LETTER OMICRON WITH PSILI AND VARIA
+	{0x1F43, 0x039F, 0},              // GREEK SMALL LETTER OMICRON WITH DASIA AND VARIA
+	{0x1F44, 0x039F, 0},              // GREEK SMALL LETTER OMICRON WITH PSILI AND OXIA
+	{0x1F45, 0x039F, 0},              // GREEK SMALL LETTER OMICRON WITH DASIA AND OXIA
+	{0x1F48, 0x039F, 0},              // GREEK CAPITAL LETTER OMICRON WITH PSILI
+	{0x1F49, 0x039F, 0},              // GREEK CAPITAL LETTER OMICRON WITH DASIA
+	{0x1F4A, 0x039F, 0},              // GREEK CAPITAL LETTER OMICRON WITH PSILI AND VARIA
+	{0x1F4B, 0x039F, 0},              // GREEK CAPITAL LETTER OMICRON WITH DASIA AND VARIA
+	{0x1F4C, 0x039F, 0},              // GREEK CAPITAL LETTER OMICRON WITH PSILI AND OXIA
+	{0x1F4D, 0x039F, 0},              // GREEK CAPITAL LETTER OMICRON WITH DASIA AND OXIA
+	{0x1F50, 0x03A5, 0},              // GREEK SMALL LETTER UPSILON WITH PSILI
+	{0x1F51, 0x03A5, 0},              // GREEK SMALL LETTER UPSILON WITH DASIA
+	{0x1F52, 0x03A5, 0},              // GREEK SMALL LETTER UPSILON WITH PSILI AND VARIA
+	{0x1F53, 0x03A5, 0},              // GREEK SMALL LETTER UPSILON WITH DASIA AND VARIA
+	{0x1F54, 0x03A5, 0},              // GREEK SMALL LETTER UPSILON WITH PSILI AND OXIA
+	{0x1F55, 0x03A5, 0},              // GREEK SMALL LETTER UPSILON WITH DASIA AND OXIA
+	{0x1F56, 0x03A5, 0},              // GREEK SMALL LETTER UPSILON WITH PSILI AND PERISPOMENI
+	{0x1F57, 0x03A5, 0},              // GREEK SMALL LETTER UPSILON WITH DASIA AND PERISPOMENI
+	{0x1F59, 0x03A5, 0},              // GREEK CAPITAL LETTER UPSILON WITH DASIA
+	{0x1F5B, 0x03A5, 0},              // GREEK CAPITAL LETTER UPSILON WITH DASIA AND VARIA
+	{0x1F5D, 0x03A5, 0},              // GREEK CAPITAL LETTER UPSILON WITH DASIA AND OXIA
+	{0x1F5F, 0x03A5, 0},              // GREEK CAPITAL LETTER UPSILON WITH DASIA AND PERISPOMENI
+	{0x1F60, 0x03A9, 0},              // GREEK SMALL LETTER OMEGA WITH PSILI
+	{0x1F61, 0x03A9, 0},              // GREEK SMALL LETTER OMEGA WITH DASIA
+	{0x1F62, 0x03A9, 0},              // GREEK SMALL LETTER OMEGA WITH PSILI AND VARIA
+	{0x1F63, 0x03A9, 0},              // GREEK SMALL LETTER OMEGA WITH DASIA AND VARIA
+	{0x1F64, 0x03A9, 0},              // GREEK SMALL LETTER OMEGA WITH PSILI AND OXIA
+	{0x1F65, 0x03A9, 0},              // GREEK SMALL LETTER OMEGA WITH DASIA AND OXIA
+	{0x1F66, 0x03A9, 0},              // GREEK SMALL LETTER OMEGA WITH PSILI AND PERISPOMENI
+	{0x1F67, 0x03A9, 0},              // GREEK SMALL LETTER OMEGA WITH DASIA AND PERISPOMENI
+	{0x1F68, 0x03A9, 0},              // GREEK CAPITAL LETTER OMEGA WITH PSILI
+	{0x1F69, 0x03A9, 0},              // GREEK CAPITAL LETTER OMEGA WITH DASIA
+	{0x1F6A, 0x03A9, 0},              // GREEK CAPITAL LETTER OMEGA WITH PSILI AND VARIA
+	{0x1F6B, 0x03A9, 0},              // GREEK CAPITAL LETTER OMEGA WITH DASIA AND VARIA
+	{0x1F6C, 0x03A9, 0},              // GREEK CAPITAL LETTER OMEGA WITH PSILI AND OXIA
+	{0x1F6D, 0x03A9, 0},              // GREEK CAPITAL LETTER OMEGA WITH DASIA AND OXIA
+	{0x1F6E, 0x03A9, 0},              // GREEK CAPITAL LETTER OMEGA WITH PSILI AND PERISPOMENI
+	{0x1F6F, 0x03A9, 0},              // GREEK CAPITAL LETTER OMEGA WITH DASIA AND PERISPOMENI
+	{0x1F70, 0x0391, 0},              // GREEK SMALL LETTER ALPHA WITH VARIA
+	{0x1F71, 0x0391, 0},              // GREEK SMALL LETTER ALPHA WITH OXIA
+	{0x1F72, 0x0395, 0},              // GREEK SMALL LETTER EPSILON WITH VARIA
+	{0x1F73, 0x0395, 0},              // GREEK SMALL LETTER EPSILON WITH OXIA
+	{0x1F74, 0x0397, 0},              // GREEK SMALL LETTER ETA WITH VARIA
+	{0x1F75, 0x0397, 0},              // GREEK SMALL LETTER ETA WITH OXIA
+	{0x1F76, 0x0399, 0},              // GREEK SMALL LETTER IOTA WITH VARIA
+	{0x1F77, 0x0399, 0},              // GREEK SMALL LETTER IOTA WITH OXIA
+	{0x1F78, 0x039F, 0},              // GREEK SMALL LETTER OMICRON WITH VARIA
+	{0x1F79, 0x039F, 0},              // GREEK SMALL LETTER OMICRON WITH OXIA
+	{0x1F7A, 0x03A5, 0},              // GREEK SMALL LETTER UPSILON WITH VARIA
+	{0x1F7B, 0x03A5, 0},              // GREEK SMALL LETTER UPSILON WITH OXIA
+	{0x1F7C, 0x03A9, 0},              // GREEK SMALL LETTER OMEGA WITH VARIA
+	{0x1F7D, 0x03A9, 0},              // GREEK SMALL LETTER OMEGA WITH OXIA
+	{0x1F80, 0x0391, greekIota},      // GREEK SMALL LETTER ALPHA WITH PSILI AND YPOGEGRAMMENI
+	{0x1F81, 0x0391, greekIota},      // GREEK SMALL LETTER ALPHA WITH DASIA AND YPOGEGRAMMENI
+	{0x1F82, 0x0391, greekIota},      // GREEK SMALL LETTER ALPHA WITH PSILI AND VARIA AND YPOGEGRAMMENI
+	{0x1F83, 0x0391, greekIota},      // GREEK SMALL LETTER ALPHA WITH DASIA AND VARIA AND YPOGEGRAMMENI
+	{0x1F84, 0x0391, greekIota},      // GREEK SMALL LETTER ALPHA WITH PSILI AND OXIA AND YPOGEGRAMMENI
+	{0x1F85, 0x0391, greekIota},      // GREEK SMALL LETTER ALPHA WITH DASIA AND OXIA AND YPOGEGRAMMENI
+	{0x1F86, 0x0391, greekIota},      // GREEK SMALL LETTER ALPHA WITH PSILI AND PERISPOMENI AND YPOGEGRAMMENI
+	{0x1F87, 0x0391, greekIota},      // GREEK SMALL LETTER ALPHA WITH DASIA AND PERISPOMENI AND YPOGEGRAMMENI
+	{0x1F88, 0x0391, greekIota},      // GREEK CAPITAL LETTER ALPHA WITH PSILI AND PROSGEGRAMMENI
+	{0x1F89, 0x0391, greekIota},      // GREEK CAPITAL LETTER ALPHA WITH DASIA AND PROSGEGRAMMENI
+	{0x1F8A, 0x0391, greekIota},      // GREEK CAPITAL LETTER ALPHA WITH PSILI AND VARIA AND PROSGEGRAMMENI
+	{0x1F8B, 0x0391, greekIota},      // GREEK CAPITAL LETTER ALPHA WITH DASIA AND VARIA AND PROSGEGRAMMENI
+	{0x1F8C, 0x0391, greekIota},      // GREEK CAPITAL LETTER ALPHA WITH PSILI AND OXIA AND PROSGEGRAMMENI
+	{0x1F8D, 0x0391, greekIota},      // GREEK CAPITAL LETTER ALPHA WITH DASIA AND OXIA AND PROSGEGRAMMENI
+	{0x1F8E, 0x0391, greekIota},      // GREEK CAPITAL LETTER ALPHA WITH PSILI AND PERISPOMENI AND PROSGEGRAMMENI
+	{0x1F8F, 0x0391, greekIota},      // GREEK CAPITAL LETTER ALPHA WITH DASIA AND PERISPOMENI AND PROSGEGRAMMENI
+	{0x1F90, 0x0397, greekIota},      // GREEK SMALL LETTER ETA WITH PSILI AND YPOGEGRAMMENI
+	{0x1F91, 0x0397, greekIota},      // GREEK SMALL LETTER ETA WITH DASIA AND YPOGEGRAMMENI
+	{0x1F92, 0x0397, greekIota},      // GREEK SMALL LETTER ETA WITH PSILI AND VARIA AND YPOGEGRAMMENI
+	{0x1F93, 0x0397, greekIota},      // GREEK SMALL LETTER ETA WITH DASIA AND VARIA AND YPOGEGRAMMENI
+	{0x1F94, 0x0397, greekIota},      // GREEK SMALL LETTER ETA WITH PSILI AND OXIA AND YPOGEGRAMMENI
+	{0x1F95, 0x0397, greekIota},      // GREEK SMALL LETTER ETA WITH DASIA AND OXIA AND YPOGEGRAMMENI
+	{0x1F96, 0x0397, greekIota},      // GREEK SMALL LETTER ETA WITH PSILI AND PERISPOMENI AND YPOGEGRAMMENI
+	{0x1F97, 0x0397, greekIota},      // GREEK SMALL LETTER ETA WITH DASIA AND PERISPOMENI AND YPOGEGRAMMENI
+	{0x1F98, 0x0397, greekIota},      // GREEK CAPITAL LETTER ETA WITH PSILI AND PROSGEGRAMMENI
+	{0x1F99, 0x0397, greekIota},      // GREEK CAPITAL LETTER ETA WITH DASIA AND PROSGEGRAMMENI
+	{0x1F9A, 0x0397, greekIota},      // GREEK CAPITAL LETTER ETA WITH PSILI AND VARIA AND PROSGEGRAMMENI
+	{0x1F9B, 0x0397, greekIota},      // GREEK CAPITAL LETTER ETA WITH DASIA AND VARIA AND PROSGEGRAMMENI
+	{0x1F9C, 0x0397, greekIota},      // GREEK CAPITAL LETTER ETA WITH PSILI AND OXIA AND PROSGEGRAMMENI
+	{0x1F9D, 0x0397, greekIota},      // GREEK CAPITAL LETTER ETA WITH DASIA AND OXIA AND PROSGEGRAMMENI
+	{0x1F9E, 0x0397, greekIota},      // GREEK CAPITAL LETTER ETA WITH PSILI AND PERISPOMENI AND PROSGEGRAMMENI
+	{0x1F9F, 0x0397, greekIota},      // GREEK CAPITAL LETTER ETA WITH DASIA AND PERISPOMENI AND PROSGEGRAMMENI
+	{0x1FA0, 0x03A9, greekIota},      // GREEK SMALL LETTER OMEGA WITH PSILI AND YPOGEGRAMMENI
+	{0x1FA1, 0x03A9, greekIota},      // GREEK SMALL LETTER OMEGA WITH DASIA AND YPOGEGRAMMENI
+	{0x1FA2, 0x03A9, greekIota},      // GREEK SMALL LETTER OMEGA WITH PSILI AND VARIA AND YPOGEGRAMMENI
+	{0x1FA3, 0x03A9, greekIota},      // GREEK SMALL LETTER OMEGA WITH DASIA AND VARIA AND YPOGEGRAMMENI
+	{0x1FA4, 0x03A9, greekIota},      // GREEK SMALL LETTER OMEGA WITH PSILI AND OXIA AND YPOGEGRAMMENI
+	{0x1FA5, 0x03A9, greekIota},      // GREEK SMALL LETTER OMEGA WITH DASIA AND OXIA AND YPOGEGRAMMENI
+	{0x1FA6, 0x03A9, greekIota},      // GREEK SMALL LETTER OMEGA WITH PSILI AND PERISPOMENI AND YPOGEGRAMMENI
+	{0x1FA7, 0x03A9, greekIota},      // GREEK SMALL LETTER OMEGA WITH DASIA AND PERISPOMENI AND YPOGEGRAMMENI
+	{0x1FA8, 0x03A9, greekIota},      // GREEK CAPITAL LETTER OMEGA WITH PSILI AND PROSGEGRAMMENI
+	{0x1FA9, 0x03A9, greekIota},      // GREEK CAPITAL LETTER OMEGA WITH DASIA AND PROSGEGRAMMENI
+	{0x1FAA, 0x03A9, greekIota},      // GREEK CAPITAL LETTER OMEGA WITH PSILI AND VARIA AND PROSGEGRAMMENI
+	{0x1FAB, 0x03A9, greekIota},      // GREEK CAPITAL LETTER OMEGA WITH DASIA AND VARIA AND PROSGEGRAMMENI
+	{0x1FAC, 0x03A9, greekIota},      // GREEK CAPITAL LETTER OMEGA WITH PSILI AND OXIA AND PROSGEGRAMMENI
+	{0x1FAD, 0x03A9, greekIota},      // GREEK CAPITAL LETTER OMEGA WITH DASIA AND OXIA AND PROSGEGRAMMENI
+	{0x1FAE, 0x03A9, greekIota},      // GREEK CAPITAL LETTER OMEGA WITH PSILI AND PERISPOMENI AND PROSGEGRAMMENI
+	{0x1FAF, 0x03A9, greekIota},      // GREEK CAPITAL LETTER OMEGA WITH DASIA AND PERISPOMENI AND PROSGEGRAMMENI
+	{0x1FB0, 0x0391, 0},              // GREEK SMALL LETTER ALPHA WITH VRACHY
+	{0x1FB1, 0x0391, 0},              // GREEK SMALL LETTER ALPHA WITH MACRON
+	{0x1FB2, 0x0391, greekIota},      // GREEK SMALL LETTER ALPHA WITH VARIA AND YPOGEGRAMMENI
+	{0x1FB3, 0x0391, greekIota},      // GREEK SMALL LETTER ALPHA WITH YPOGEGRAMMENI
+	{0x1FB4, 0x0391, greekIota},      // GREEK SMALL LETTER ALPHA WITH OXIA AND YPOGEGRAMMENI
+	{0x1FB6, 0x0391, 0},              // GREEK SMALL LETTER ALPHA WITH PERISPOMENI
+	{0x1FB7, 0x0391, greekIota},      // GREEK SMALL LETTER ALPHA WITH PERISPOMENI AND YPOGEGRAMMENI
+	{0x1FB8, 0x0391, 0},              // GREEK CAPITAL LETTER ALPHA WITH VRACHY
+	{0x1FB9, 0x0391, 0},              // GREEK CAPITAL LETTER ALPHA WITH MACRON
+	{0x1FBA, 0x0391, 0},              // GREEK CAPITAL LETTER ALPHA WITH VARIA
+	{0x1FBB, 0x0391, 0},              // GREEK CAPITAL LETTER ALPHA WITH OXIA
+	{0x1FBC, 0x0391, greekIota},      // GREEK CAPITAL LETTER ALPHA WITH PROSGEGRAMMENI
+	{0x1FC2, 0x0397, greekIota},      // GREEK SMALL LETTER ETA WITH VARIA AND YPOGEGRAMMENI
+	{0x1FC3, 0x0397, greekIota},      // GREEK SMALL LETTER ETA WITH YPOGEGRAMMENI
+	{0x1FC4, 0x0397, greekIota},      // GREEK SMALL LETTER ETA WITH OXIA AND YPOGEGRAMMENI
+	{0x1FC6, 0x0397, 0},              // GREEK SMALL LETTER ETA WITH PERISPOMENI
+	{0x1FC7, 0x0397, greekIota},      // GREEK SMALL LETTER ETA WITH PERISPOMENI AND YPOGEGRAMMENI
+	{0x1FC8, 0x0395, 0},              // GREEK CAPITAL LETTER EPSILON WITH VARIA
+	{0x1FC9, 0x0395, 0},              // GREEK CAPITAL LETTER EPSILON WITH OXIA
+	{0x1FCA, 0x0397, 0},              // GREEK CAPITAL LETTER ETA WITH VARIA
+	{0x1FCB, 0x0397, 0},              // GREEK CAPITAL LETTER ETA WITH OXIA
+	{0x1FCC, 0x0397, greekIota},      // GREEK CAPITAL LETTER ETA WITH PROSGEGRAMMENI
+	{0x1FD0, 0x0399, 0},              // GREEK SMALL LETTER IOTA WITH VRACHY
+	{0x1FD1, 0x0399, 0},              // GREEK SMALL LETTER IOTA WITH MACRON
+	{0x1FD2, 0x0399, greekDialytika}, // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND VARIA
+	{0x1FD3, 0x0399, greekDialytika}, // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND OXIA
+	{0x1FD6, 0x0399, 0},              // GREEK SMALL LETTER IOTA WITH PERISPOMENI
+	{0x1FD7, 0x0399, greekDialytika}, // GREEK SMALL LETTER IOTA WITH DIALYTIKA AND PERISPOMENI
+	{0x1FD8, 0x0399, 0},              // GREEK CAPITAL LETTER IOTA WITH VRACHY
+	{0x1FD9, 0x0399, 0},              // GREEK CAPITAL LETTER IOTA WITH MACRON
+	{0x1FDA, 0x0399, 0},              // GREEK CAPITAL LETTER IOTA WITH VARIA
+	{0x1FDB, 0x0399, 0},              // GREEK CAPITAL LETTER IOTA WITH OXIA
+	{0x1FE0, 0x03A5, 0},              // GREEK SMALL LETTER UPSILON WITH VRACHY
+	{0x1FE1, 0x03A5, 0},              // GREEK SMALL LETTER UPSILON WITH MACRON
+	{0x1FE2, 0x03A5, greekDialytika}, // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND VARIA
+	{0x1FE3, 0x03A5, greekDialytika}, // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND OXIA
+	{0x1FE4, 0x03A1, 0},              // GREEK SMALL LETTER RHO WITH PSILI
+	{0x1FE5, 0x03A1, 0},              // GREEK SMALL LETTER RHO WITH DASIA
+	{0x1FE6, 0x03A5, 0},              // GREEK SMALL LETTER UPSILON WITH PERISPOMENI
+	{0x1FE7, 0x03A5, greekDialytika}, // GREEK SMALL LETTER UPSILON WITH DIALYTIKA AND PERISPOMENI
+	{0x1FE8, 0x03A5, 0},              // GREEK CAPITAL LETTER UPSILON WITH VRACHY
+	{0x1FE9, 0x03A5, 0},              // GREEK CAPITAL LETTER UPSILON WITH MACRON
+	{0x1FEA, 0x03A5, 0},              // GREEK CAPITAL LETTER UPSILON WITH VARIA
+	{0x1FEB, 0x03A5, 0},              // GREEK CAPITAL LETTER UPSILON WITH OXIA
+	{0x1FEC, 0x03A1, 0},              // GREEK CAPITAL LETTER RHO WITH DASIA
+	{0x1FF2, 0x03A9, greekIota},      // GREEK SMALL LETTER OMEGA WITH VARIA AND YPOGEGRAMMENI
+	{0x1FF3, 0x03A9, greekIota},      // GREEK SMALL LETTER OMEGA WITH YPOGEGRAMMENI
+	{0x1FF4, 0x03A9, greekIota},      // GREEK SMALL LETTER OMEGA WITH OXIA AND YPOGEGRAMMENI
+	{0x1FF6, 0x03A9, 0},              // GREEK SMALL LETTER OMEGA WITH PERISPOMENI
+	{0x1FF7, 0x03A9, greekIota},      // GREEK SMALL LETTER OMEGA WITH PERISPOMENI AND YPOGEGRAMMENI
+	{0x1FF8, 0x039F, 0},              // GREEK CAPITAL LETTER OMICRON WITH VARIA
+	{0x1FF9, 0x039F, 0},              // GREEK CAPITAL LETTER OMICRON WITH OXIA
+	{0x1FFA, 0x03A9, 0},              // GREEK CAPITAL LETTER OMEGA WITH VARIA
+	{0x1FFB, 0x03A9, 0},              // GREEK CAPITAL LETTER OMEGA WITH OXIA
+	{0x1FFC, 0x03A9, greekIota},      // GREEK CAPITAL LETTER OMEGA WITH PROSGEGRAMMENI
+}
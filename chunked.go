@@ -0,0 +1,185 @@
+package strings2
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// DefaultChunkSize is the chunk size of a ChunkedBuilder created with a
+// size <= 0.
+const DefaultChunkSize = 64 << 10
+
+// ChunkedBuilder builds very large strings in a list of fixed-size chunks
+// instead of one contiguous buffer. Writes never copy what was written
+// before, so building n bytes needs about n bytes of memory rather than
+// the up to 3n of a doubling buffer at the moment it grows. The chunks can
+// be streamed with WriteTo; they are only concatenated by String.
+type ChunkedBuilder struct {
+	noCopy    noCopy
+	chunks    [][]byte // full chunks, or the result of the last String
+	cur       []byte   // chunk being written
+	chunkSize int
+	n         int
+}
+
+var (
+	_ io.Writer       = (*ChunkedBuilder)(nil)
+	_ io.ByteWriter   = (*ChunkedBuilder)(nil)
+	_ io.StringWriter = (*ChunkedBuilder)(nil)
+	_ io.ReaderFrom   = (*ChunkedBuilder)(nil)
+	_ io.WriterTo     = (*ChunkedBuilder)(nil)
+)
+
+// NewChunkedBuilder returns a ChunkedBuilder that allocates chunks of
+// chunkSize bytes, or DefaultChunkSize if chunkSize <= 0.
+func NewChunkedBuilder(chunkSize int) *ChunkedBuilder {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	return &ChunkedBuilder{chunkSize: chunkSize}
+}
+
+// Len returns the number of accumulated bytes.
+func (b *ChunkedBuilder) Len() int { return b.n }
+
+// next makes room in b.cur, starting a new chunk if it is full.
+func (b *ChunkedBuilder) next() {
+	if len(b.cur) > 0 {
+		b.chunks = append(b.chunks, b.cur)
+	}
+	if b.chunkSize == 0 {
+		b.chunkSize = DefaultChunkSize
+	}
+	b.cur = MakeNoZeroCap(0, b.chunkSize)
+}
+
+// WriteString appends the contents of s to b, filling the current chunk
+// and starting new ones as needed.
+// It returns the length of s and a nil error.
+func (b *ChunkedBuilder) WriteString(s string) (int, error) {
+	n := len(s)
+	for len(s) > 0 {
+		if len(b.cur) == cap(b.cur) {
+			b.next()
+		}
+		m := min(len(s), cap(b.cur)-len(b.cur))
+		b.cur = append(b.cur, s[:m]...)
+		s = s[m:]
+	}
+	b.n += n
+	return n, nil
+}
+
+// Write appends the contents of p to b.
+// Write always returns len(p), nil.
+func (b *ChunkedBuilder) Write(p []byte) (int, error) {
+	return b.WriteString(unsafeString(p))
+}
+
+// WriteByte appends the byte c to b.
+// The returned error is always nil.
+func (b *ChunkedBuilder) WriteByte(c byte) error {
+	if len(b.cur) == cap(b.cur) {
+		b.next()
+	}
+	b.cur = append(b.cur, c)
+	b.n++
+	return nil
+}
+
+// WriteRune appends the UTF-8 encoding of Unicode code point r to b. The
+// encoding may be split between two chunks.
+// It returns the length of r and a nil error.
+func (b *ChunkedBuilder) WriteRune(r rune) (int, error) {
+	if uint32(r) < utf8.RuneSelf {
+		return 1, b.WriteByte(byte(r))
+	}
+	var tmp [utf8.UTFMax]byte
+	return b.Write(utf8.AppendRune(tmp[:0], r))
+}
+
+// ReadFrom reads data from r until EOF directly into the chunks of b.
+// The return value n is the number of bytes read. Any error except
+// io.EOF encountered during the read is also returned.
+func (b *ChunkedBuilder) ReadFrom(r io.Reader) (n int64, err error) {
+	for {
+		if len(b.cur) == cap(b.cur) {
+			b.next()
+		}
+		l := len(b.cur)
+		m, e := r.Read(b.cur[l:cap(b.cur)])
+		if m < 0 {
+			panic("strings2.ChunkedBuilder.ReadFrom: reader returned negative count from Read")
+		}
+		b.cur = b.cur[:l+m]
+		b.n += m
+		n += int64(m)
+		if e == io.EOF {
+			return n, nil
+		}
+		if e != nil {
+			return n, e
+		}
+	}
+}
+
+// WriteTo writes the accumulated bytes to w chunk by chunk, without
+// concatenating them. The builder keeps its contents. The return value n
+// is the number of bytes written; any error encountered during the write
+// is also returned.
+func (b *ChunkedBuilder) WriteTo(w io.Writer) (n int64, err error) {
+	for _, c := range b.chunks {
+		m, err := writeChunk(w, c)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	m, err := writeChunk(w, b.cur)
+	return n + m, err
+}
+
+func writeChunk(w io.Writer, c []byte) (int64, error) {
+	if len(c) == 0 {
+		return 0, nil
+	}
+	m, err := w.Write(c)
+	if m > len(c) {
+		panic("strings2.ChunkedBuilder.WriteTo: invalid Write count")
+	}
+	if err == nil && m != len(c) {
+		err = io.ErrShortWrite
+	}
+	return int64(m), err
+}
+
+// String returns the accumulated string. Data that fits in one chunk is
+// returned without copying. Otherwise the chunks are concatenated into
+// one allocation, which then replaces them, so calling String again
+// without writing in between is free.
+func (b *ChunkedBuilder) String() string {
+	switch {
+	case len(b.chunks) == 0:
+		return unsafeString(b.cur)
+	case len(b.chunks) == 1 && len(b.cur) == 0:
+		return unsafeString(b.chunks[0])
+	}
+	buf := MakeNoZero(b.n)
+	off := 0
+	for _, c := range b.chunks {
+		off += copy(buf[off:], c)
+	}
+	copy(buf[off:], b.cur)
+	clear(b.chunks)
+	b.chunks = append(b.chunks[:0], buf)
+	// The rest of the current chunk stays in use for later writes.
+	b.cur = b.cur[len(b.cur):]
+	return unsafeString(buf)
+}
+
+// Reset resets b to be empty, releasing all chunks.
+func (b *ChunkedBuilder) Reset() {
+	b.chunks = nil
+	b.cur = nil
+	b.n = 0
+}
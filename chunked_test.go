@@ -0,0 +1,105 @@
+package strings2
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestChunkedBuilder(t *testing.T) {
+	for _, size := range []int{1, 3, 16, 0} {
+		b := NewChunkedBuilder(size)
+		var want strings.Builder
+		for i := range 200 {
+			s := strings.Repeat(string(rune('a'+i%26)), i%40)
+			b.WriteString(s)
+			want.WriteString(s)
+			b.WriteByte(',')
+			want.WriteByte(',')
+			b.WriteRune('ж')
+			want.WriteRune('ж')
+			b.Write([]byte("€"))
+			want.WriteString("€")
+		}
+		b.ReadFrom(strings.NewReader(strings.Repeat("r", 1000)))
+		want.WriteString(strings.Repeat("r", 1000))
+
+		if b.Len() != want.Len() {
+			t.Fatalf("size %d: Len = %d, want %d", size, b.Len(), want.Len())
+		}
+		var out bytes.Buffer
+		if n, err := b.WriteTo(&out); err != nil || n != int64(want.Len()) || out.String() != want.String() {
+			t.Fatalf("size %d: WriteTo n=%d err=%v, content match=%v", size, n, err, out.String() == want.String())
+		}
+		if got := b.String(); got != want.String() {
+			t.Fatalf("size %d: String mismatch", size)
+		}
+		// String again is free, and writes after it keep working.
+		s1, s2 := b.String(), b.String()
+		if unsafe.StringData(s1) != unsafe.StringData(s2) {
+			t.Fatalf("size %d: second String copied", size)
+		}
+		b.WriteString("tail")
+		if got := b.String(); got != want.String()+"tail" {
+			t.Fatalf("size %d: String after more writes mismatch", size)
+		}
+		if s1 != want.String() {
+			t.Fatalf("size %d: earlier String result changed", size)
+		}
+
+		b.Reset()
+		if b.Len() != 0 || b.String() != "" {
+			t.Fatalf("size %d: Reset left data", size)
+		}
+	}
+}
+
+func TestChunkedBuilderNoCopy(t *testing.T) {
+	var b ChunkedBuilder // zero value is usable
+	b.WriteString("small")
+	if b.String() != "small" || unsafe.StringData(b.String()) != &b.cur[0] {
+		t.Fatal("single chunk should be returned without copying")
+	}
+
+	c := NewChunkedBuilder(1024)
+	chunk := strings.Repeat("x", 1024)
+	allocs := testing.AllocsPerRun(1, func() {
+		c.Reset()
+		for range 64 {
+			c.WriteString(chunk)
+		}
+	})
+	// One allocation per chunk plus the growth of the chunk list.
+	if allocs > 64+8 {
+		t.Fatalf("too many allocs: %v", allocs)
+	}
+}
+
+func BenchmarkChunkedBuilder(b *testing.B) {
+	line := strings.Repeat("report line ", 8) + "\n"
+	b.ReportAllocs()
+	for b.Loop() {
+		c := NewChunkedBuilder(0)
+		for range 100000 {
+			c.WriteString(line)
+		}
+		c.WriteTo(discard{})
+	}
+}
+
+func BenchmarkBuilderLarge(b *testing.B) {
+	line := strings.Repeat("report line ", 8) + "\n"
+	b.ReportAllocs()
+	for b.Loop() {
+		c := NewBuilder(0)
+		for range 100000 {
+			c.WriteString(line)
+		}
+		c.WriteTo(discard{})
+	}
+}
+
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) { return len(p), nil }
@@ -0,0 +1,21 @@
+//go:build !strings2_nocopycheck
+
+package strings2
+
+// copyCheckEnabled reports whether Builder detects copies at run time.
+const copyCheckEnabled = true
+
+// copyCheck panics if b was copied by value after its first use, as
+// strings.Builder does. Two copies sharing a buffer would otherwise
+// overwrite each other's bytes, including bytes of strings already
+// returned by String.
+//
+// Build with the strings2_nocopycheck tag to remove the check from hot
+// paths.
+func (b *Builder) copyCheck() {
+	if b.addr == nil {
+		b.addr = noescapeBuilder(b)
+	} else if b.addr != b {
+		panic("strings2: illegal use of non-zero Builder copied by value")
+	}
+}
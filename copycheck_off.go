@@ -0,0 +1,7 @@
+//go:build strings2_nocopycheck
+
+package strings2
+
+const copyCheckEnabled = false
+
+func (b *Builder) copyCheck() {}
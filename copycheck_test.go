@@ -0,0 +1,79 @@
+package strings2
+
+import (
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// copyBuilder copies src into dst by value. A plain assignment would be
+// rejected by vet's copylocks check, which is exactly what the run-time
+// check backs up.
+func copyBuilder(dst, src *Builder) {
+	reflect.ValueOf(dst).Elem().Set(reflect.ValueOf(src).Elem())
+}
+
+func TestBuilderCopyPanics(t *testing.T) {
+	writes := []struct {
+		name string
+		fn   func(b *Builder)
+	}{
+		{"Write", func(b *Builder) { b.Write([]byte("x")) }},
+		{"WriteByte", func(b *Builder) { b.WriteByte('x') }},
+		{"WriteRune", func(b *Builder) { b.WriteRune('x') }},
+		{"WriteString", func(b *Builder) { b.WriteString("x") }},
+		{"Grow", func(b *Builder) { b.Grow(1) }},
+		{"Detach", func(b *Builder) { b.Detach() }},
+		{"Truncate", func(b *Builder) { b.Truncate(0) }},
+		{"ReplaceRange", func(b *Builder) { b.ReplaceRange(0, 0, "x") }},
+		{"ResetAndKeepCap", func(b *Builder) { b.ResetAndKeepCap() }},
+		{"ReadFrom", func(b *Builder) { b.ReadFrom(errReader{io.EOF}) }},
+		{"WriteInt", func(b *Builder) { b.WriteInt(1) }},
+		{"WriteUint", func(b *Builder) { b.WriteUint(1) }},
+		{"WriteHex", func(b *Builder) { b.WriteHex(1) }},
+		{"WriteFloat", func(b *Builder) { b.WriteFloat(1, 'g', -1, 64) }},
+		{"WriteBool", func(b *Builder) { b.WriteBool(true) }},
+		{"WriteTime", func(b *Builder) { b.WriteTime(time.Time{}, time.Kitchen) }},
+		{"WriteQuoted", func(b *Builder) { b.WriteQuoted("x") }},
+		{"BuilderPool.Put", func(b *Builder) { NewBuilderPool(0, false).Put(b) }},
+	}
+	for _, w := range writes {
+		t.Run(w.name, func(t *testing.T) {
+			var zero Builder
+			zero.WriteString("used")
+			fromNew := NewBuilder(64)
+			fromNew.WriteByte('x')
+			unused := NewBuilder(16) // owns a buffer before any write
+			for _, orig := range []*Builder{&zero, fromNew, unused} {
+				var cp Builder
+				copyBuilder(&cp, orig)
+				panicked := func() (p bool) {
+					defer func() { p = recover() != nil }()
+					w.fn(&cp)
+					return
+				}()
+				if panicked != copyCheckEnabled {
+					t.Fatalf("copied Builder: panicked=%v, want %v", panicked, copyCheckEnabled)
+				}
+			}
+		})
+	}
+}
+
+func TestBuilderCopyAllowed(t *testing.T) {
+	// Copying a zero Builder, or a Builder after Reset, is allowed.
+	var a, b Builder
+	copyBuilder(&b, &a)
+	b.WriteString("ok")
+
+	a.WriteString("used")
+	a.Reset()
+	var c Builder
+	copyBuilder(&c, &a)
+	c.WriteString("ok")
+	a.WriteString("still ok")
+	if a.String() != "still ok" || c.String() != "ok" {
+		t.Fatal("unexpected content after Reset and copy")
+	}
+}
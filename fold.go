@@ -0,0 +1,357 @@
+package strings2
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Case-insensitive search and replace. Two runes match when they are
+// equal under simple Unicode case folding, exactly as in EqualFold.
+// Because some folds change the UTF-8 length (the Kelvin sign U+212A
+// matches 'k'), a match in s is not necessarily len(substr) bytes long.
+
+// asciiFoldsToASCII[c] reports that every rune in the simple fold orbit
+// of the ASCII byte c is ASCII too. It is false only for 'K', 'k', 'S'
+// and 's' (Kelvin sign and long s).
+var asciiFoldsToASCII = func() [utf8.RuneSelf]bool {
+	var table [utf8.RuneSelf]bool
+	for i := range table {
+		table[i] = true
+		for r := unicode.SimpleFold(rune(i)); r != rune(i); r = unicode.SimpleFold(r) {
+			if r >= utf8.RuneSelf {
+				table[i] = false
+			}
+		}
+	}
+	return table
+}()
+
+// foldPrefix reports whether s starts with a case-insensitive match of
+// prefix, and returns the length in bytes of the match in s. It is the
+// comparison behind EqualFold, so its unrolled upperTable loop is the only
+// one in the package.
+func foldPrefix(s, prefix string) (int, bool) {
+	table := upperTable
+	n := min(len(s), len(prefix))
+	i := 0
+
+	// Unroll by 4
+	limit := n &^ 3
+	for i < limit {
+		s0, p0 := s[i+0], prefix[i+0]
+		if s0|p0 >= utf8.RuneSelf {
+			goto hasUnicode
+		}
+		if table[s0] != table[p0] {
+			return 0, false
+		}
+
+		s1, p1 := s[i+1], prefix[i+1]
+		if s1|p1 >= utf8.RuneSelf {
+			goto hasUnicode
+		}
+		if table[s1] != table[p1] {
+			return 0, false
+		}
+
+		s2, p2 := s[i+2], prefix[i+2]
+		if s2|p2 >= utf8.RuneSelf {
+			goto hasUnicode
+		}
+		if table[s2] != table[p2] {
+			return 0, false
+		}
+
+		s3, p3 := s[i+3], prefix[i+3]
+		if s3|p3 >= utf8.RuneSelf {
+			goto hasUnicode
+		}
+		if table[s3] != table[p3] {
+			return 0, false
+		}
+
+		i += 4
+	}
+
+	for i < n {
+		si, pi := s[i], prefix[i]
+		if si|pi >= utf8.RuneSelf {
+			goto hasUnicode
+		}
+		if table[si] != table[pi] {
+			return 0, false
+		}
+		i++
+	}
+	// All ASCII so far: s either covers prefix or is too short.
+	return n, n == len(prefix)
+
+hasUnicode:
+	// Fall back to Unicode-aware path. The byte offsets into s and prefix
+	// diverge from here on when a fold changes the encoded length.
+	j := i
+	for j < len(prefix) {
+		if i >= len(s) {
+			return 0, false
+		}
+		var sr, pr rune
+		var ss, ps int
+		if s[i] < utf8.RuneSelf {
+			sr, ss = rune(s[i]), 1
+		} else {
+			sr, ss = utf8.DecodeRuneInString(s[i:])
+		}
+		if prefix[j] < utf8.RuneSelf {
+			pr, ps = rune(prefix[j]), 1
+		} else {
+			pr, ps = utf8.DecodeRuneInString(prefix[j:])
+		}
+		if !equalFoldRune(sr, pr) {
+			return 0, false
+		}
+		i += ss
+		j += ps
+	}
+	return i, true
+}
+
+// foldSuffix reports whether s ends with a case-insensitive match of
+// suffix, and returns the length in bytes of the match in s.
+func foldSuffix(s, suffix string) (int, bool) {
+	i, j := len(s), len(suffix)
+	for i > 0 && j > 0 {
+		sc, pc := s[i-1], suffix[j-1]
+		if sc|pc >= utf8.RuneSelf {
+			break
+		}
+		if upperTable[sc] != upperTable[pc] {
+			return 0, false
+		}
+		i, j = i-1, j-1
+	}
+	// Fall back to Unicode-aware path, which also reports an s that is
+	// too short.
+	for j > 0 {
+		if i <= 0 {
+			return 0, false
+		}
+		var sr, pr rune
+		var ss, ps int
+		if s[i-1] < utf8.RuneSelf {
+			sr, ss = rune(s[i-1]), 1
+		} else {
+			sr, ss = utf8.DecodeLastRuneInString(s[:i])
+		}
+		if suffix[j-1] < utf8.RuneSelf {
+			pr, ps = rune(suffix[j-1]), 1
+		} else {
+			pr, ps = utf8.DecodeLastRuneInString(suffix[:j])
+		}
+		if !equalFoldRune(sr, pr) {
+			return 0, false
+		}
+		i -= ss
+		j -= ps
+	}
+	return len(s) - i, true
+}
+
+// HasPrefixFold reports whether s begins with prefix under simple Unicode
+// case folding. The matched part of s may differ in length from prefix.
+func HasPrefixFold(s, prefix string) bool {
+	_, ok := foldPrefix(s, prefix)
+	return ok
+}
+
+// HasSuffixFold reports whether s ends with suffix under simple Unicode
+// case folding. The matched part of s may differ in length from suffix.
+func HasSuffixFold(s, suffix string) bool {
+	_, ok := foldSuffix(s, suffix)
+	return ok
+}
+
+// TrimPrefixFold returns s without the provided leading prefix, matched
+// case-insensitively. If s doesn't start with prefix, s is returned
+// unchanged.
+func TrimPrefixFold(s, prefix string) string {
+	if n, ok := foldPrefix(s, prefix); ok {
+		return s[n:]
+	}
+	return s
+}
+
+// TrimSuffixFold returns s without the provided trailing suffix, matched
+// case-insensitively. If s doesn't end with suffix, s is returned
+// unchanged.
+func TrimSuffixFold(s, suffix string) string {
+	if n, ok := foldSuffix(s, suffix); ok {
+		return s[:len(s)-n]
+	}
+	return s
+}
+
+// CutPrefixFold returns s without the provided leading prefix, matched
+// case-insensitively, and reports whether it found the prefix.
+func CutPrefixFold(s, prefix string) (after string, found bool) {
+	n, ok := foldPrefix(s, prefix)
+	if !ok {
+		return s, false
+	}
+	return s[n:], true
+}
+
+// CutSuffixFold returns s without the provided trailing suffix, matched
+// case-insensitively, and reports whether it found the suffix.
+func CutSuffixFold(s, suffix string) (before string, found bool) {
+	n, ok := foldSuffix(s, suffix)
+	if !ok {
+		return s, false
+	}
+	return s[:len(s)-n], true
+}
+
+// isASCIINoLetters reports whether s is ASCII without any letters, in
+// which case case-insensitive matching is plain byte matching.
+func isASCIINoLetters(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= utf8.RuneSelf || lowerTable[c] != upperTable[c] {
+			return false
+		}
+	}
+	return true
+}
+
+// indexFold returns the bounds of the first case-insensitive match of
+// substr in s, or -1, -1.
+func indexFold(s, substr string) (start, end int) {
+	if len(substr) == 0 {
+		return 0, 0
+	}
+	if isASCIINoLetters(substr) {
+		if i := strings.Index(s, substr); i >= 0 {
+			return i, i + len(substr)
+		}
+		return -1, -1
+	}
+
+	c0 := substr[0]
+	if c0 < utf8.RuneSelf && asciiFoldsToASCII[c0] {
+		// The first rune can only match one of two bytes.
+		lc, uc := lowerTable[c0], upperTable[c0]
+		for i := 0; i < len(s); i++ {
+			if c := s[i]; c != lc && c != uc {
+				continue
+			}
+			if n, ok := foldPrefix(s[i:], substr); ok {
+				return i, i + n
+			}
+		}
+		return -1, -1
+	}
+
+	for i := 0; i < len(s); {
+		if n, ok := foldPrefix(s[i:], substr); ok {
+			return i, i + n
+		}
+		if s[i] < utf8.RuneSelf {
+			i++
+		} else {
+			_, wid := utf8.DecodeRuneInString(s[i:])
+			i += wid
+		}
+	}
+	return -1, -1
+}
+
+// IndexFold returns the index of the first instance of substr in s under
+// simple Unicode case folding, or -1 if substr is not present in s.
+func IndexFold(s, substr string) int {
+	i, _ := indexFold(s, substr)
+	return i
+}
+
+// LastIndexFold returns the index of the last instance of substr in s
+// under simple Unicode case folding, or -1 if substr is not present in s.
+func LastIndexFold(s, substr string) int {
+	if len(substr) == 0 {
+		return len(s)
+	}
+	if isASCIINoLetters(substr) {
+		return strings.LastIndex(s, substr)
+	}
+	for i := len(s) - 1; i >= 0; i-- {
+		if !utf8.RuneStart(s[i]) {
+			continue
+		}
+		if _, ok := foldPrefix(s[i:], substr); ok {
+			return i
+		}
+	}
+	return -1
+}
+
+// ContainsFold reports whether substr is within s under simple Unicode
+// case folding.
+func ContainsFold(s, substr string) bool {
+	i, _ := indexFold(s, substr)
+	return i >= 0
+}
+
+// CountFold counts the number of non-overlapping instances of substr in s
+// under simple Unicode case folding. If substr is empty, CountFold
+// returns 1 + the number of Unicode code points in s.
+func CountFold(s, substr string) int {
+	if len(substr) == 0 {
+		return utf8.RuneCountInString(s) + 1
+	}
+	n := 0
+	for {
+		i, j := indexFold(s, substr)
+		if i < 0 {
+			return n
+		}
+		n++
+		s = s[j:]
+	}
+}
+
+// ReplaceFold returns a copy of s with the first n non-overlapping
+// case-insensitive instances of old replaced by new. If n < 0, there is
+// no limit on the number of replacements. If old is empty it behaves like
+// ReplaceString. If nothing matched, s itself is returned.
+func ReplaceFold(s, old, new string, n int) string {
+	if n == 0 || len(s) == 0 {
+		return s
+	}
+	if isASCIINoLetters(old) {
+		// Also covers old == "".
+		return ReplaceString(s, old, new, n)
+	}
+	if n < 0 {
+		n = 1 << 30
+	}
+
+	start, end := indexFold(s, old)
+	if start < 0 {
+		return s //zerocalloc
+	}
+
+	b := NewBuilder(len(s) + max(len(new)-len(old), 0))
+	pos := 0
+	for count := 0; count < n && start >= 0; count++ {
+		b.WriteString(s[pos : pos+start])
+		b.WriteString(new)
+		pos += end
+		start, end = indexFold(s[pos:], old)
+	}
+	b.WriteString(s[pos:])
+	return b.String()
+}
+
+// ReplaceAllFold returns a copy of s with all case-insensitive instances
+// of old replaced by new.
+func ReplaceAllFold(s, old, new string) string {
+	return ReplaceFold(s, old, new, -1)
+}
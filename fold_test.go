@@ -0,0 +1,269 @@
+package strings2
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// naiveIndexFold is a reference implementation on top of strings.EqualFold.
+func naiveIndexFold(s, substr string) (int, int) {
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) && !utf8.RuneStart(s[i]) {
+			continue
+		}
+		for j := i; j <= len(s); j++ {
+			if (j == len(s) || utf8.RuneStart(s[j])) && strings.EqualFold(s[i:j], substr) {
+				return i, j
+			}
+		}
+	}
+	return -1, -1
+}
+
+var foldCases = []struct {
+	s, substr string
+}{
+	{"/MY/NAME/IS/:PARAM/*", "/my/name/is/:param/*"},
+	{"/my/name/IS/:PARAM/*", "name/is"},
+	{"/my/name/is/:param/*", ":PARAM"},
+	{"Content-Type: text/html", "content-type"},
+	{"X-Forwarded-For", "FOR"},
+	{"hello", "HELLO WORLD"},
+	{"hello world", "xyz"},
+	{"hello world", ""},
+	{"", "a"},
+	{"", ""},
+	{"abc/:def", "/:"},
+	{"ПРИВЕТ мир", "привет"},
+	{"мир ПРИВЕТ", "привет"},
+	{"ΣΕΙΣ σεις", "ΣΕΙΣ"},
+	{"HÅLL håll", "åll"},
+	{"\u212Aelvin", "kelvin"},
+	{"kelvin", "\u212AELVIN"},
+	{"ma\u017F\u017F", "MASS"},
+	{"xx\u212A", "k"},
+	{"aaaa", "AA"},
+	{"ababab", "BAB"},
+	{"Straße", "STRASSE"},
+	{"😀HELLO😀hello", "Hello"},
+}
+
+func TestIndexFold(t *testing.T) {
+	for _, tc := range foldCases {
+		want, _ := naiveIndexFold(tc.s, tc.substr)
+		if got := IndexFold(tc.s, tc.substr); got != want {
+			t.Errorf("IndexFold(%q, %q) = %d, want %d", tc.s, tc.substr, got, want)
+		}
+		if got := ContainsFold(tc.s, tc.substr); got != (want >= 0) {
+			t.Errorf("ContainsFold(%q, %q) = %v, want %v", tc.s, tc.substr, got, want >= 0)
+		}
+	}
+}
+
+func TestLastIndexFold(t *testing.T) {
+	for _, tc := range foldCases {
+		want := -1
+		if tc.substr == "" {
+			want = len(tc.s)
+		}
+		for i := 0; i < len(tc.s) && tc.substr != ""; i++ {
+			if !utf8.RuneStart(tc.s[i]) {
+				continue
+			}
+			if j, _ := naiveIndexFold(tc.s[i:], tc.substr); j == 0 {
+				want = i
+			}
+		}
+		if got := LastIndexFold(tc.s, tc.substr); got != want {
+			t.Errorf("LastIndexFold(%q, %q) = %d, want %d", tc.s, tc.substr, got, want)
+		}
+	}
+}
+
+func TestCountFold(t *testing.T) {
+	tests := []struct {
+		s, substr string
+		want      int
+	}{
+		{"Hello HELLO hello", "hello", 3},
+		{"aaaa", "AA", 2},
+		{"abc", "", 4},
+		{"привет ПРИВЕТ", "Привет", 2},
+		{"\u212A k \u212A", "k", 3},
+		{"nothing", "x", 0},
+	}
+	for _, tt := range tests {
+		if got := CountFold(tt.s, tt.substr); got != tt.want {
+			t.Errorf("CountFold(%q, %q) = %d, want %d", tt.s, tt.substr, got, tt.want)
+		}
+	}
+}
+
+func TestReplaceFold(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		old  string
+		new  string
+		n    int
+		want string
+	}{
+		{"ascii", "Hello HELLO hello", "hello", "hi", -1, "hi hi hi"},
+		{"n limit", "Hello HELLO hello", "hello", "hi", 2, "hi hi hello"},
+		{"n = 0", "Hello", "hello", "hi", 0, "Hello"},
+		{"grow", "a A a", "a", "xyz", -1, "xyz xyz xyz"},
+		{"route", "/MY/NAME/IS/:PARAM", "/my/name", "/your/name", -1, "/your/name/IS/:PARAM"},
+		{"no letters", "a::b::c", "::", "/", -1, "a/b/c"},
+		{"cyrillic", "Привет ПРИВЕТ привет", "привет", "hi", -1, "hi hi hi"},
+		{"kelvin", "100\u212A and 5k", "k", "K", -1, "100K and 5K"},
+		{"empty old", "ab", "", "-", -1, "-a-b-"},
+		{"no match", "hello", "xyz", "1", -1, "hello"},
+		{"delete", "FooBarFOO", "foo", "", -1, "Bar"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReplaceFold(tt.s, tt.old, tt.new, tt.n); got != tt.want {
+				t.Fatalf("ReplaceFold(%q, %q, %q, %d) = %q, want %q", tt.s, tt.old, tt.new, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceFoldNoMatchNoAlloc(t *testing.T) {
+	s := strings.Repeat("abc ", 100)
+	allocs := testing.AllocsPerRun(10, func() { ReplaceFold(s, "XYZ", "1", -1) })
+	if allocs != 0 {
+		t.Fatalf("expected 0 allocs, got %v", allocs)
+	}
+}
+
+func BenchmarkIndexFold(b *testing.B) {
+	s := strings.Repeat("accept-encoding: gzip\r\n", 40) + "Content-Type: text/html"
+	b.ReportAllocs()
+	for b.Loop() {
+		IndexFold(s, "content-type")
+	}
+}
+
+func BenchmarkIndexToLower(b *testing.B) {
+	s := strings.Repeat("accept-encoding: gzip\r\n", 40) + "Content-Type: text/html"
+	b.ReportAllocs()
+	for b.Loop() {
+		strings.Index(strings.ToLower(s), "content-type")
+	}
+}
+
+// naiveFoldPrefix and naiveFoldSuffix are reference implementations on top
+// of strings.EqualFold.
+func naiveFoldPrefix(s, prefix string) (int, bool) {
+	for j := 0; j <= len(s); j++ {
+		if (j == len(s) || utf8.RuneStart(s[j])) && strings.EqualFold(s[:j], prefix) {
+			return j, true
+		}
+	}
+	return 0, false
+}
+
+func naiveFoldSuffix(s, suffix string) (int, bool) {
+	for i := len(s); i >= 0; i-- {
+		if (i == len(s) || utf8.RuneStart(s[i])) && strings.EqualFold(s[i:], suffix) {
+			return len(s) - i, true
+		}
+	}
+	return 0, false
+}
+
+var affixFoldCases = []struct {
+	s, affix string
+}{
+	{"Content-Type: text/html", "content-type"},
+	{"Content-Type: text/html", "TEXT/HTML"},
+	{"/MY/NAME/IS/:PARAM/*", "/my/name/is/:param/*"},
+	{"/MY/NAME/IS/:PARAM/*", "/my/name"},
+	{"/MY/NAME/IS/:PARAM/*", ":param/*"},
+	{"Bearer abcdef", "bearer "},
+	{"Basic abcdef", "bearer "},
+	{"abc", "abcd"},
+	{"abc", ""},
+	{"", ""},
+	{"", "a"},
+	{"abcdefghij", "ABCDEFGHIJ"},
+	{"abcdefghij", "ABCDEFGHIX"},
+	{"ПРИВЕТ мир", "привет"},
+	{"мир ПРИВЕТ", "привет"},
+	{"abcd ПРИВЕТ efgh", "ABCD привет EFGH"},
+	{"\u212Aelvin", "kelvin"},
+	{"kelvin", "\u212AELVIN"},
+	{"\u212Aelvin\u212A", "kelvink"},
+	{"ma\u017F\u017F", "MASS"},
+	{"MASS", "ma\u017F\u017F"},
+	{"abc\u212A", "ABCk"},
+	{"\u212Aabc", "kABC"},
+	{"😀HELLO", "😀hello"},
+	{"HELLO😀", "hello😀"},
+	{"ΣΕΙΣ σεις", "σεις"},
+}
+
+func TestHasPrefixSuffixFold(t *testing.T) {
+	for _, tc := range affixFoldCases {
+		wantN, want := naiveFoldPrefix(tc.s, tc.affix)
+		if got := HasPrefixFold(tc.s, tc.affix); got != want {
+			t.Errorf("HasPrefixFold(%q, %q) = %v, want %v", tc.s, tc.affix, got, want)
+		}
+		wantTrim := tc.s
+		if want {
+			wantTrim = tc.s[wantN:]
+		}
+		if got := TrimPrefixFold(tc.s, tc.affix); got != wantTrim {
+			t.Errorf("TrimPrefixFold(%q, %q) = %q, want %q", tc.s, tc.affix, got, wantTrim)
+		}
+		if got, found := CutPrefixFold(tc.s, tc.affix); got != wantTrim || found != want {
+			t.Errorf("CutPrefixFold(%q, %q) = %q, %v, want %q, %v", tc.s, tc.affix, got, found, wantTrim, want)
+		}
+
+		wantN, want = naiveFoldSuffix(tc.s, tc.affix)
+		if got := HasSuffixFold(tc.s, tc.affix); got != want {
+			t.Errorf("HasSuffixFold(%q, %q) = %v, want %v", tc.s, tc.affix, got, want)
+		}
+		wantTrim = tc.s
+		if want {
+			wantTrim = tc.s[:len(tc.s)-wantN]
+		}
+		if got := TrimSuffixFold(tc.s, tc.affix); got != wantTrim {
+			t.Errorf("TrimSuffixFold(%q, %q) = %q, want %q", tc.s, tc.affix, got, wantTrim)
+		}
+		if got, found := CutSuffixFold(tc.s, tc.affix); got != wantTrim || found != want {
+			t.Errorf("CutSuffixFold(%q, %q) = %q, %v, want %q, %v", tc.s, tc.affix, got, found, wantTrim, want)
+		}
+	}
+}
+
+func TestPrefixFoldNoAlloc(t *testing.T) {
+	s := "Content-Type: application/json; charset=utf-8"
+	allocs := testing.AllocsPerRun(100, func() {
+		benchSink = HasPrefixFold(s, "CONTENT-TYPE")
+		benchSink = HasSuffixFold(s, "UTF-8")
+		_ = TrimPrefixFold(s, "content-type: ")
+	})
+	if allocs != 0 {
+		t.Fatalf("expected 0 allocs, got %v", allocs)
+	}
+}
+
+func BenchmarkHasPrefixFold(b *testing.B) {
+	s := "Content-Type: application/json; charset=utf-8"
+	b.ReportAllocs()
+	for b.Loop() {
+		benchSink = HasPrefixFold(s, "content-type")
+	}
+}
+
+func BenchmarkHasPrefixEqualFold(b *testing.B) {
+	s := "Content-Type: application/json; charset=utf-8"
+	p := "content-type"
+	b.ReportAllocs()
+	for b.Loop() {
+		benchSink = len(s) >= len(p) && EqualFold(s[:len(p)], p)
+	}
+}
@@ -0,0 +1,291 @@
+package strings2
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// formatterMap maps a dynamic type to the function that formats its
+// values.
+type formatterMap map[reflect.Type]func(any) string
+
+// formatters is the registry consulted by ToString. It is replaced, never
+// modified, so lookups only load a pointer.
+var (
+	formattersMu sync.Mutex
+	formatters   atomic.Pointer[formatterMap]
+)
+
+// RegisterFormatter makes ToString, ToStringWith and AppendToString
+// format values of type T, and pointers to them, with f instead of their
+// String method or reflection. It also overrides the default formatting
+// of time.Time, though not that of the other types ToString handles
+// directly. StringOf and AppendString do not use formatters. A nil f
+// removes the formatter for T.
+//
+// T must be a concrete type; ToString looks formatters up by the dynamic
+// type of its argument. RegisterFormatter is safe for concurrent use, also
+// with ToString, but is meant for initialization.
+func RegisterFormatter[T any](f func(T) string) {
+	var g func(any) string
+	if f != nil {
+		g = func(v any) string { return f(v.(T)) }
+	}
+	RegisterFormatterType(reflect.TypeFor[T](), g)
+}
+
+// RegisterFormatterType is RegisterFormatter for a type only known at run
+// time. f is called with values whose dynamic type is t.
+func RegisterFormatterType(t reflect.Type, f func(any) string) {
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+	m := formatterMap{}
+	if old := formatters.Load(); old != nil {
+		for k, v := range *old {
+			m[k] = v
+		}
+	}
+	if f == nil {
+		delete(m, t)
+	} else {
+		m[t] = f
+	}
+	formatters.Store(&m)
+}
+
+func lookupFormatter(arg any) (func(any) string, bool) {
+	return lookupFormatterType(reflect.TypeOf(arg))
+}
+
+func lookupFormatterType(t reflect.Type) (func(any) string, bool) {
+	m := formatters.Load()
+	if m == nil || len(*m) == 0 {
+		return nil, false
+	}
+	f, ok := (*m)[t]
+	return f, ok
+}
+
+// formatOptions carries the settings of one ToString call into the
+// values it formats recursively.
+type formatOptions struct {
+	timeFormat []string
+	overrides  formatterMap
+	fieldNames bool
+	maxDepth   int       // 0: DefaultMaxDepth
+	depth      int       // composite values entered so far
+	path       []uintptr // pointers and maps being formatted
+}
+
+// DefaultMaxDepth is how deeply ToString descends into nested pointers,
+// slices, arrays, maps and structs unless WithMaxDepth says otherwise.
+const DefaultMaxDepth = 32
+
+func (o *formatOptions) formatter(arg any) (func(any) string, bool) {
+	return o.formatterType(reflect.TypeOf(arg))
+}
+
+func (o *formatOptions) formatterType(t reflect.Type) (func(any) string, bool) {
+	if o.overrides != nil {
+		if f, ok := o.overrides[t]; ok {
+			return f, true
+		}
+	}
+	return lookupFormatterType(t)
+}
+
+// A FormatOption changes how ToStringWith formats a value.
+type FormatOption func(*formatOptions)
+
+// WithTimeFormat formats time.Time values with layout, like the
+// timeFormat argument of ToString.
+func WithTimeFormat(layout string) FormatOption {
+	return func(o *formatOptions) {
+		o.timeFormat = []string{layout}
+	}
+}
+
+// WithFormatter formats values of type T with f for this call only,
+// taking precedence over any formatter registered for T.
+func WithFormatter[T any](f func(T) string) FormatOption {
+	return func(o *formatOptions) {
+		if o.overrides == nil {
+			o.overrides = formatterMap{}
+		}
+		o.overrides[reflect.TypeFor[T]()] = func(v any) string { return f(v.(T)) }
+	}
+}
+
+// WithFieldNames writes struct fields as Name:value, like the %+v verb,
+// instead of just their values.
+func WithFieldNames() FormatOption {
+	return func(o *formatOptions) {
+		o.fieldNames = true
+	}
+}
+
+// WithMaxDepth limits how deeply nested composite values are formatted;
+// values below the limit are written as <max depth>.
+func WithMaxDepth(n int) FormatOption {
+	return func(o *formatOptions) {
+		o.maxDepth = max(n, 1)
+	}
+}
+
+// ToStringWith is ToString with per-call options. Options apply to arg
+// and to the elements and pointees formatted on its behalf.
+func ToStringWith(arg any, opts ...FormatOption) string {
+	var o formatOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return toString(arg, o)
+}
+
+// formatValue formats the values ToString does not handle directly:
+//
+//   - nil, nil pointers and nil interfaces as <nil>, and nil maps as map[]
+//   - pointers as the value they point to
+//   - slices and arrays as [e1 e2]
+//   - maps as map[k1:v1 k2:v2], with keys sorted as by the fmt package
+//   - structs as {v1 v2}, or {Name1:v1 Name2:v2} with WithFieldNames
+//
+// Elements are formatted by ToString's rules, so registered formatters,
+// errors and String methods apply to them too. A pointer or map that
+// contains itself is written as <cycle>, and values nested deeper than
+// the depth limit as <max depth>. Anything else is left to fmt.Sprint.
+func formatValue(rv reflect.Value, o formatOptions) string {
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return "<nil>"
+	case reflect.Interface:
+		if rv.IsNil() {
+			return "<nil>"
+		}
+		return formatElem(rv.Elem(), o)
+	case reflect.Pointer, reflect.Map:
+		if rv.IsNil() {
+			if rv.Kind() == reflect.Map {
+				return "map[]"
+			}
+			return "<nil>"
+		}
+		if slices.Contains(o.path, rv.Pointer()) {
+			return "<cycle>"
+		}
+	case reflect.Slice, reflect.Array, reflect.Struct:
+	default:
+		return fmt.Sprint(rv)
+	}
+
+	if o.maxDepth == 0 {
+		o.maxDepth = DefaultMaxDepth
+	}
+	if o.depth >= o.maxDepth {
+		return "<max depth>"
+	}
+	o.depth++
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		o.path = append(o.path, rv.Pointer())
+		return formatElem(rv.Elem(), o)
+	case reflect.Slice, reflect.Array:
+		var buf = NewBuilder(rv.Len())
+		buf.WriteString("[") //nolint:errcheck // no need to check error
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				buf.WriteString(" ") //nolint:errcheck // no need to check error
+			}
+			buf.WriteString(formatElem(rv.Index(i), o)) //nolint:errcheck // no need to check error
+		}
+		buf.WriteString("]") //nolint:errcheck // no need to check error
+		return buf.String()
+	case reflect.Map:
+		o.path = append(o.path, rv.Pointer())
+		keys := rv.MapKeys()
+		slices.SortFunc(keys, compareMapKeys)
+		var buf = NewBuilder(4 + 8*len(keys))
+		buf.WriteString("map[") //nolint:errcheck // no need to check error
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteString(" ") //nolint:errcheck // no need to check error
+			}
+			buf.WriteString(formatElem(k, o))              //nolint:errcheck // no need to check error
+			buf.WriteString(":")                           //nolint:errcheck // no need to check error
+			buf.WriteString(formatElem(rv.MapIndex(k), o)) //nolint:errcheck // no need to check error
+		}
+		buf.WriteString("]") //nolint:errcheck // no need to check error
+		return buf.String()
+	}
+
+	// reflect.Struct
+	t := rv.Type()
+	var buf = NewBuilder(2 + 8*rv.NumField())
+	buf.WriteString("{") //nolint:errcheck // no need to check error
+	for i := 0; i < rv.NumField(); i++ {
+		if i > 0 {
+			buf.WriteString(" ") //nolint:errcheck // no need to check error
+		}
+		if o.fieldNames {
+			buf.WriteString(t.Field(i).Name) //nolint:errcheck // no need to check error
+			buf.WriteString(":")             //nolint:errcheck // no need to check error
+		}
+		buf.WriteString(formatElem(rv.Field(i), o)) //nolint:errcheck // no need to check error
+	}
+	buf.WriteString("}") //nolint:errcheck // no need to check error
+	return buf.String()
+}
+
+// formatElem formats an element of a composite value. Values read from
+// unexported fields cannot be turned back into an interface, so they are
+// formatted by kind alone, without formatters or methods.
+func formatElem(v reflect.Value, o formatOptions) string {
+	if v.CanInterface() {
+		return toString(v.Interface(), o)
+	}
+	return formatValue(v, o)
+}
+
+// compareMapKeys orders map keys as the fmt package does: numbers, strings
+// and booleans by value, pointers by address, and values of different
+// types in an interface by type name. Other keys are compared by their
+// formatted form.
+func compareMapKeys(a, b reflect.Value) int {
+	if a.Kind() == reflect.Interface {
+		a, b = a.Elem(), b.Elem()
+		if !a.IsValid() || !b.IsValid() {
+			return cmp.Compare(boolInt(a.IsValid()), boolInt(b.IsValid()))
+		}
+		if a.Type() != b.Type() {
+			return strings.Compare(a.Type().String(), b.Type().String())
+		}
+	}
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case reflect.Bool:
+		return cmp.Compare(boolInt(a.Bool()), boolInt(b.Bool()))
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return cmp.Compare(a.Pointer(), b.Pointer())
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
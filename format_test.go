@@ -0,0 +1,249 @@
+package strings2
+
+import (
+	"fmt"
+	"net/netip"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testMoney struct{ cents int64 }
+
+type testID [4]byte
+
+func (id testID) String() string { return "stringer" }
+
+type testPtrID struct{ n int }
+
+func (id *testPtrID) String() string { return "stringer" }
+
+func TestRegisterFormatter(t *testing.T) {
+	RegisterFormatter(func(m testMoney) string {
+		return fmt.Sprintf("$%d.%02d", m.cents/100, m.cents%100)
+	})
+	RegisterFormatter(func(id testID) string { return fmt.Sprintf("%x", id[:]) })
+	RegisterFormatter(func(id testPtrID) string { return fmt.Sprint("id-", id.n) })
+	RegisterFormatterType(reflect.TypeFor[netip.Prefix](), func(v any) string {
+		return "net:" + v.(netip.Prefix).String()
+	})
+	t.Cleanup(func() {
+		RegisterFormatter[testMoney](nil)
+		RegisterFormatter[testID](nil)
+		RegisterFormatter[testPtrID](nil)
+		RegisterFormatterType(reflect.TypeFor[netip.Prefix](), nil)
+	})
+
+	m := testMoney{12345}
+	tests := []struct {
+		arg  any
+		want string
+	}{
+		{m, "$123.45"},
+		{&m, "$123.45"},
+		{[]testMoney{{1}, {250}}, "[$0.01 $2.50]"},
+		{testID{0xde, 0xad, 0xbe, 0xef}, "deadbeef"}, // registry before String
+		{&testID{0xca, 0xfe, 0, 1}, "cafe0001"},
+		{&testPtrID{7}, "id-7"}, // registry before String on *T
+		{netip.MustParsePrefix("10.0.0.0/8"), "net:10.0.0.0/8"},
+		{reflect.ValueOf(m), "$123.45"},
+		{42, "42"}, // built-in types are not affected
+	}
+	for _, tt := range tests {
+		if got := ToString(tt.arg); got != tt.want {
+			t.Errorf("ToString(%#v) = %q, want %q", tt.arg, got, tt.want)
+		}
+		if got := string(AppendToString([]byte(">"), tt.arg)); got != ">"+tt.want {
+			t.Errorf("AppendToString(%#v) = %q, want %q", tt.arg, got, ">"+tt.want)
+		}
+	}
+
+	RegisterFormatter[testID](nil)
+	if got := ToString(testID{}); got != "stringer" {
+		t.Fatalf("after removal: %q", got)
+	}
+}
+
+func TestRegisterFormatterTime(t *testing.T) {
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	RegisterFormatter(func(t time.Time) string { return t.Format(time.RFC3339) })
+	t.Cleanup(func() { RegisterFormatter[time.Time](nil) })
+	if got, want := ToString(tm), "2020-01-02T03:04:05Z"; got != want {
+		t.Fatalf("ToString(time) = %q, want %q", got, want)
+	}
+	if got, want := string(AppendToString(nil, tm)), "2020-01-02T03:04:05Z"; got != want {
+		t.Fatalf("AppendToString(time) = %q, want %q", got, want)
+	}
+	if got, want := StringOf(tm), "2020-01-02 03:04:05"; got != want {
+		t.Fatalf("StringOf(time) = %q, want %q: StringOf ignores the registry", got, want)
+	}
+}
+
+func TestToStringWith(t *testing.T) {
+	RegisterFormatter(func(m testMoney) string { return "registered" })
+	t.Cleanup(func() { RegisterFormatter[testMoney](nil) })
+
+	m := testMoney{5}
+	if got := ToStringWith(m); got != "registered" {
+		t.Fatalf("no options: %q", got)
+	}
+	override := WithFormatter(func(m testMoney) string { return fmt.Sprintf("%d cents", m.cents) })
+	if got := ToStringWith([]*testMoney{&m, &m}, override); got != "[5 cents 5 cents]" {
+		t.Fatalf("override: %q", got)
+	}
+	if got := ToString(m); got != "registered" {
+		t.Fatalf("override leaked into ToString: %q", got)
+	}
+
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	if got := ToStringWith([]time.Time{tm}, WithTimeFormat(time.Kitchen)); got != "[3:04AM]" {
+		t.Fatalf("WithTimeFormat: %q", got)
+	}
+}
+
+func TestRegisterFormatterConcurrent(t *testing.T) {
+	t.Cleanup(func() { RegisterFormatter[testMoney](nil) })
+	var wg sync.WaitGroup
+	for g := range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				RegisterFormatter(func(m testMoney) string { return fmt.Sprint(g, i) })
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				ToString(testMoney{1})
+				ToString(g)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+type testNode struct {
+	Name string
+	Next *testNode
+}
+
+type testErr struct{ code int }
+
+func (e *testErr) Error() string { return fmt.Sprintf("error %d", e.code) }
+
+type testNilStringer struct{ s string }
+
+func (p *testNilStringer) String() string {
+	if p == nil {
+		return "nilS"
+	}
+	return p.s
+}
+
+type testMixed struct {
+	ID    int
+	tags  []string
+	Err   error
+	Inner *testMixed
+}
+
+func TestToStringComposite(t *testing.T) {
+	var nilNode *testNode
+	var nilErr *testErr
+	tests := []struct {
+		arg  any
+		want string
+	}{
+		{nil, "<nil>"},
+		{nilNode, "<nil>"},
+		{nilErr, "<nil>"}, // Error panics on a nil receiver
+		{(*testNilStringer)(nil), "nilS"},
+		{[]*testNilStringer{nil}, "[nilS]"},
+		{&testErr{7}, "error 7"},
+		{error(&testErr{8}), "error 8"},
+		{map[string]int{"b": 2, "a": 1, "c": 3}, "map[a:1 b:2 c:3]"},
+		{map[int]string{10: "x", -1: "y", 2: "z"}, "map[-1:y 2:z 10:x]"},
+		{map[float64]bool{2.5: true, -1: false}, "map[-1:false 2.5:true]"},
+		{map[bool][]int{true: {1}, false: nil}, "map[false:[] true:[1]]"},
+		{map[any]int{"s": 1, 2: 2}, "map[2:2 s:1]"},
+		{map[string]int(nil), "map[]"},
+		{testNode{Name: "a"}, "{a <nil>}"},
+		{&testNode{Name: "a", Next: &testNode{Name: "b"}}, "{a {b <nil>}}"},
+		{testMixed{ID: 1, tags: []string{"x", "y"}, Err: &testErr{3}}, "{1 [x y] error 3 <nil>}"},
+		{[]any{1, "two", nil, []int{3}}, "[1 two <nil> [3]]"},
+	}
+	for _, tt := range tests {
+		if got := ToString(tt.arg); got != tt.want {
+			t.Errorf("ToString(%#v) = %q, want %q", tt.arg, got, tt.want)
+		}
+	}
+
+	// Deterministic: the same map always formats the same way.
+	m := map[string]int{}
+	for i := range 50 {
+		m[fmt.Sprint("k", i)] = i
+	}
+	first := ToString(m)
+	for range 10 {
+		if ToString(m) != first {
+			t.Fatal("map output not deterministic")
+		}
+	}
+	if first != fmt.Sprint(m) {
+		t.Fatalf("map order differs from fmt:\n%s\n%s", first, fmt.Sprint(m))
+	}
+}
+
+func TestToStringFieldNames(t *testing.T) {
+	v := testMixed{ID: 1, tags: []string{"x"}, Inner: &testMixed{ID: 2}}
+	got := ToStringWith(v, WithFieldNames())
+	want := "{ID:1 tags:[x] Err:<nil> Inner:{ID:2 tags:[] Err:<nil> Inner:<nil>}}"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestToStringCycles(t *testing.T) {
+	n := &testNode{Name: "loop"}
+	n.Next = n
+	if got, want := ToString(n), "{loop <cycle>}"; got != want {
+		t.Fatalf("pointer cycle: %q, want %q", got, want)
+	}
+
+	a := &testNode{Name: "a"}
+	b := &testNode{Name: "b", Next: a}
+	a.Next = b
+	if got, want := ToString(a), "{a {b <cycle>}}"; got != want {
+		t.Fatalf("two-node cycle: %q, want %q", got, want)
+	}
+
+	m := map[string]any{"k": 1}
+	m["self"] = m
+	if got, want := ToString(m), "map[k:1 self:<cycle>]"; got != want {
+		t.Fatalf("map cycle: %q, want %q", got, want)
+	}
+
+	// The same pointer twice, but not nested in itself, is not a cycle.
+	shared := &testNode{Name: "s"}
+	if got, want := ToString([]*testNode{shared, shared}), "[{s <nil>} {s <nil>}]"; got != want {
+		t.Fatalf("shared pointer: %q, want %q", got, want)
+	}
+
+	s := []any{nil}
+	s[0] = s
+	got := ToString(s)
+	if !strings.Contains(got, "<max depth>") || strings.Count(got, "[") != DefaultMaxDepth {
+		t.Fatalf("self-containing slice: %q", got)
+	}
+
+	deep := &testNode{Name: "0"}
+	for i := 1; i < 10; i++ {
+		deep = &testNode{Name: fmt.Sprint(i), Next: deep}
+	}
+	if got, want := ToStringWith(deep, WithMaxDepth(4)), "{9 {8 <max depth>}}"; got != want {
+		t.Fatalf("WithMaxDepth: %q, want %q", got, want)
+	}
+}
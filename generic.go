@@ -0,0 +1,117 @@
+package strings2
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+	"unsafe"
+)
+
+// Formattable is the set of types StringOf and AppendString accept: the
+// integer, float, string, []byte and bool kinds, including named types
+// such as time.Month or time.Duration, and time.Time.
+type Formattable interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string | ~[]byte | ~bool | time.Time
+}
+
+// StringOf returns the string form of v without boxing v in an interface
+// that escapes or consulting reflection on the value: the formatter is
+// chosen from the kind of T alone. For the predeclared types, []byte and
+// time.Time the result is the one ToString gives by default. Integers
+// below 1000 do not allocate.
+//
+// Unlike ToString, StringOf never consults the formatter registry, so a
+// formatter registered for time.Time does not apply and time.Time is
+// always written in the "2006-01-02 15:04:05" layout; calling one would
+// make every argument escape. Nor does StringOf call String or Error
+// methods: values of named types are formatted by their kind, so a
+// time.Duration is written as a count of nanoseconds.
+//
+// Like ToString, StringOf returns a []byte argument without copying.
+func StringOf[T Formattable](v T) string {
+	if t, ok := any(v).(time.Time); ok {
+		return t.Format("2006-01-02 15:04:05")
+	}
+	p := unsafe.Pointer(&v)
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int:
+		return formatInt(int64(*(*int)(p)))
+	case reflect.Int8:
+		return formatInt(int64(*(*int8)(p)))
+	case reflect.Int16:
+		return formatInt(int64(*(*int16)(p)))
+	case reflect.Int32:
+		return formatInt(int64(*(*int32)(p)))
+	case reflect.Int64:
+		return formatInt(*(*int64)(p))
+	case reflect.Uint:
+		return formatUint(uint64(*(*uint)(p)))
+	case reflect.Uint8:
+		return formatUint(uint64(*(*uint8)(p)))
+	case reflect.Uint16:
+		return formatUint(uint64(*(*uint16)(p)))
+	case reflect.Uint32:
+		return formatUint(uint64(*(*uint32)(p)))
+	case reflect.Uint64:
+		return formatUint(*(*uint64)(p))
+	case reflect.Uintptr:
+		return formatUint(uint64(*(*uintptr)(p)))
+	case reflect.Float32:
+		return strconv.FormatFloat(float64(*(*float32)(p)), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(*(*float64)(p), 'f', -1, 64)
+	case reflect.String:
+		return *(*string)(p)
+	case reflect.Slice:
+		return unsafeString(*(*[]byte)(p))
+	case reflect.Bool:
+		return strconv.FormatBool(*(*bool)(p))
+	}
+	panic("unreachable")
+}
+
+// AppendString appends the StringOf form of v to dst and returns the
+// extended buffer. Nothing is allocated unless dst has to grow.
+func AppendString[T Formattable](dst []byte, v T) []byte {
+	if t, ok := any(v).(time.Time); ok {
+		return t.AppendFormat(dst, "2006-01-02 15:04:05")
+	}
+	p := unsafe.Pointer(&v)
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int:
+		return appendInt(dst, int64(*(*int)(p)))
+	case reflect.Int8:
+		return appendInt(dst, int64(*(*int8)(p)))
+	case reflect.Int16:
+		return appendInt(dst, int64(*(*int16)(p)))
+	case reflect.Int32:
+		return appendInt(dst, int64(*(*int32)(p)))
+	case reflect.Int64:
+		return appendInt(dst, *(*int64)(p))
+	case reflect.Uint:
+		return appendUint(dst, uint64(*(*uint)(p)))
+	case reflect.Uint8:
+		return appendUint(dst, uint64(*(*uint8)(p)))
+	case reflect.Uint16:
+		return appendUint(dst, uint64(*(*uint16)(p)))
+	case reflect.Uint32:
+		return appendUint(dst, uint64(*(*uint32)(p)))
+	case reflect.Uint64:
+		return appendUint(dst, *(*uint64)(p))
+	case reflect.Uintptr:
+		return appendUint(dst, uint64(*(*uintptr)(p)))
+	case reflect.Float32:
+		return strconv.AppendFloat(dst, float64(*(*float32)(p)), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.AppendFloat(dst, *(*float64)(p), 'f', -1, 64)
+	case reflect.String:
+		return append(dst, *(*string)(p)...)
+	case reflect.Slice:
+		return append(dst, *(*[]byte)(p)...)
+	case reflect.Bool:
+		return strconv.AppendBool(dst, *(*bool)(p))
+	}
+	panic("unreachable")
+}
@@ -0,0 +1,111 @@
+package strings2
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func checkStringOf[T Formattable](t *testing.T, v T) {
+	t.Helper()
+	want := ToString(v)
+	if got := StringOf(v); got != want {
+		t.Errorf("StringOf(%T(%v)) = %q, want %q", v, v, got, want)
+	}
+	if got := string(AppendString([]byte("x="), v)); got != "x="+want {
+		t.Errorf("AppendString(%T(%v)) = %q, want %q", v, v, got, "x="+want)
+	}
+}
+
+func TestStringOf(t *testing.T) {
+	checkStringOf(t, 0)
+	checkStringOf(t, -42)
+	checkStringOf(t, math.MaxInt)
+	checkStringOf(t, int8(-128))
+	checkStringOf(t, int16(1234))
+	checkStringOf(t, int32(-99999))
+	checkStringOf(t, int64(math.MinInt64))
+	checkStringOf(t, uint(7))
+	checkStringOf(t, uint8(255))
+	checkStringOf(t, uint16(65535))
+	checkStringOf(t, uint32(1<<31))
+	checkStringOf(t, uint64(math.MaxUint64))
+	checkStringOf(t, uintptr(4096))
+	checkStringOf(t, float32(3.14))
+	checkStringOf(t, 2.5e-10)
+	checkStringOf(t, "text")
+	checkStringOf(t, []byte("bytes"))
+	checkStringOf(t, true)
+	checkStringOf(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+}
+
+type (
+	userID  int64
+	label   string
+	rawText []byte
+	ratio   float32
+	flag    bool
+)
+
+func TestStringOfNamed(t *testing.T) {
+	checkStringOf(t, userID(-42))
+	checkStringOf(t, label("name"))
+	checkStringOf(t, ratio(0.25))
+	checkStringOf(t, flag(true))
+	if got := StringOf(rawText("raw")); got != "raw" {
+		t.Errorf("StringOf(rawText) = %q, want %q", got, "raw")
+	}
+	if got := StringOf(time.Month(3)); got != "3" {
+		t.Errorf("StringOf(time.Month(3)) = %q, want %q", got, "3")
+	}
+	if got := string(AppendString(nil, 2*time.Second)); got != "2000000000" {
+		t.Errorf("AppendString(2s) = %q, want %q", got, "2000000000")
+	}
+	// Named types are formatted by kind on purpose, without their String
+	// methods, so StringOf and ToString differ for them.
+	if got, str := StringOf(time.Duration(5)), ToString(time.Duration(5)); got != "5" || str != "5ns" {
+		t.Errorf("StringOf(5ns) = %q, ToString = %q; want %q and %q", got, str, "5", "5ns")
+	}
+	if got, str := StringOf(time.March), ToString(time.March); got != "3" || str != "March" {
+		t.Errorf("StringOf(time.March) = %q, ToString = %q; want %q and %q", got, str, "3", "March")
+	}
+}
+
+func TestStringOfNoAlloc(t *testing.T) {
+	buf := make([]byte, 0, 256)
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	n, f := 123456789, 2.5
+	allocs := testing.AllocsPerRun(100, func() {
+		StringOf(42)
+		StringOf(uint8(200))
+		StringOf("s")
+		StringOf(true)
+		StringOf(userID(7))
+		b := AppendString(buf[:0], n)
+		b = AppendString(b, f)
+		b = AppendString(b, tm)
+		b = AppendString(b, []byte("raw"))
+		b = AppendString(b, time.Duration(n))
+		_ = b
+	})
+	if allocs != 0 {
+		t.Fatalf("expected 0 allocs, got %v", allocs)
+	}
+}
+
+func BenchmarkStringOfInt(b *testing.B) {
+	x := 123456
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = StringOf(x)
+	}
+}
+
+func BenchmarkAppendStringInt(b *testing.B) {
+	buf := make([]byte, 0, 64)
+	x := 123456
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = AppendString(buf[:0], x)
+	}
+}
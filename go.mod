@@ -2,4 +2,4 @@ module github.com/NikoMalik/strings2
 
 go 1.24.4
 
-require github.com/NikoMalik/strconv2 v0.0.0-20251119202519-e9cac212aea0 // indirect
+require github.com/NikoMalik/strconv2 v0.0.0-20251119202519-e9cac212aea0
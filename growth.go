@@ -0,0 +1,103 @@
+package strings2
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrTooLarge is returned, or passed to panic by methods without an error
+// result, when a write would take a Builder past its maximum size.
+var ErrTooLarge = errors.New("strings2.Builder: too large")
+
+// A GrowthPolicy returns the capacity of the new buffer when a Builder
+// with capacity oldCap needs room for minCap bytes. Results below minCap
+// are raised to it, and results above the maximum size are lowered to it.
+type GrowthPolicy func(oldCap, minCap int) int
+
+// GrowDouble doubles the capacity, or grows to exactly what is needed if
+// that is more.
+func GrowDouble(oldCap, minCap int) int {
+	return max(2*oldCap, minCap)
+}
+
+// GrowExact grows to exactly what is needed. It wastes no memory but
+// copies the contents on every grow, so it suits builders that are sized
+// up front with Grow.
+func GrowExact(oldCap, minCap int) int {
+	return minCap
+}
+
+// GrowAppend returns the policy of the runtime's append: the capacity
+// doubles while it is below threshold and then grows by a factor moving
+// smoothly from 2 to 1.25. With threshold 256 it matches append for
+// byte slices, apart from the rounding to size classes.
+func GrowAppend(threshold int) GrowthPolicy {
+	threshold = max(threshold, 1)
+	return func(oldCap, minCap int) int {
+		if 2*oldCap < minCap {
+			return minCap
+		}
+		if oldCap < threshold {
+			return 2 * oldCap
+		}
+		newCap := oldCap
+		for newCap < minCap {
+			newCap += (newCap + 3*threshold) >> 2
+		}
+		return newCap
+	}
+}
+
+// SetGrowthPolicy sets how b grows its buffer. A nil policy restores the
+// default of 2*cap+n, where n is the number of bytes being written.
+func (b *Builder) SetGrowthPolicy(p GrowthPolicy) {
+	b.growth = p
+}
+
+// SetMaxSize limits b to n bytes, or removes the limit if n <= 0. A write
+// that would exceed the limit fails as a whole, whatever the current
+// capacity: Write, WriteByte, WriteRune, WriteString and ReadFrom return
+// ErrTooLarge, and methods without an error result panic with it.
+func (b *Builder) SetMaxSize(n int) {
+	b.maxSize = max(n, 0)
+}
+
+// ensure makes room for n more bytes, or returns ErrTooLarge if they do
+// not fit within the maximum size, whatever the current capacity.
+func (b *Builder) ensure(n int) error {
+	if b.maxSize > 0 && n > b.maxSize-len(b.buf) {
+		return ErrTooLarge
+	}
+	if cap(b.buf)-len(b.buf) < n {
+		return b.grow(n)
+	}
+	return nil
+}
+
+// reserve is ensure for methods without an error result.
+func (b *Builder) reserve(n int) {
+	if err := b.ensure(n); err != nil {
+		panic(err)
+	}
+}
+
+// grow reallocates b.buf, without zeroing, so that it has room for n more
+// bytes.
+func (b *Builder) grow(n int) error {
+	if n > math.MaxInt-len(b.buf) || b.maxSize > 0 && len(b.buf)+n > b.maxSize {
+		return ErrTooLarge
+	}
+	need := len(b.buf) + n
+	c := 2*cap(b.buf) + n
+	if b.growth != nil {
+		c = b.growth(cap(b.buf), need)
+	}
+	c = max(c, need)
+	if b.maxSize > 0 {
+		c = min(c, b.maxSize)
+	}
+	buf := MakeNoZero(c)[:len(b.buf)]
+	copy(buf, b.buf)
+	b.buf = buf
+	return nil
+}
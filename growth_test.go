@@ -0,0 +1,207 @@
+package strings2
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGrowthPolicies(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy GrowthPolicy
+		caps   []int
+	}{
+		{"default", nil, []int{100, 300, 300}},
+		{"double", GrowDouble, []int{100, 200, 400}},
+		{"exact", GrowExact, []int{100, 200, 300}},
+		{"append", GrowAppend(150), []int{100, 200, 362}},
+	}
+	for _, tt := range tests {
+		b := NewBuilder(0)
+		b.SetGrowthPolicy(tt.policy)
+		for i, want := range tt.caps {
+			b.WriteString(strings.Repeat("x", 100))
+			if b.Cap() != want {
+				t.Errorf("%s: cap after write %d = %d, want %d", tt.name, i, b.Cap(), want)
+			}
+		}
+		if b.Len() != 100*len(tt.caps) {
+			t.Errorf("%s: Len = %d", tt.name, b.Len())
+		}
+	}
+
+	b := NewBuilder(0)
+	b.SetGrowthPolicy(func(oldCap, minCap int) int { return 1 })
+	b.WriteString("raised to minCap")
+	if b.String() != "raised to minCap" {
+		t.Fatalf("custom policy: %q", b.String())
+	}
+}
+
+func TestGrowAppend(t *testing.T) {
+	grow := GrowAppend(256)
+	for _, tt := range []struct{ old, min, want int }{
+		{0, 10, 10},
+		{100, 101, 200},
+		{100, 500, 500},
+		{256, 257, 512},
+		{512, 513, 832},
+		{1 << 20, 1<<20 + 1, 1<<20 + (1<<20+768)>>2},
+	} {
+		if got := grow(tt.old, tt.min); got != tt.want {
+			t.Errorf("GrowAppend(256)(%d, %d) = %d, want %d", tt.old, tt.min, got, tt.want)
+		}
+	}
+	if got := GrowAppend(0)(1, 2); got != 2 {
+		t.Errorf("GrowAppend(0)(1, 2) = %d", got)
+	}
+}
+
+func TestBuilderMaxSize(t *testing.T) {
+	b := NewBuilder(0)
+	b.SetMaxSize(10)
+	if n, err := b.WriteString("12345678"); n != 8 || err != nil {
+		t.Fatalf("WriteString: n=%d err=%v", n, err)
+	}
+	if b.Cap() > 10 {
+		t.Fatalf("cap %d above max size", b.Cap())
+	}
+	if n, err := b.WriteString("abc"); n != 0 || err != ErrTooLarge {
+		t.Fatalf("WriteString over max: n=%d err=%v", n, err)
+	}
+	if n, err := b.Write([]byte("abc")); n != 0 || err != ErrTooLarge {
+		t.Fatalf("Write over max: n=%d err=%v", n, err)
+	}
+	if n, err := b.WriteRune('ж'); n != 2 || err != nil {
+		t.Fatalf("WriteRune: n=%d err=%v", n, err)
+	}
+	if err := b.WriteByte('x'); err != ErrTooLarge {
+		t.Fatalf("WriteByte over max: err=%v", err)
+	}
+	if b.String() != "12345678ж" {
+		t.Fatalf("content changed by failed writes: %q", b.String())
+	}
+
+	for name, f := range map[string]func(){
+		"Grow":         func() { b.Grow(1) },
+		"WriteInt":     func() { b.WriteInt(10) },
+		"WriteQuoted":  func() { b.WriteQuoted("") },
+		"InsertString": func() { b.InsertString(0, "x") },
+	} {
+		func() {
+			defer func() {
+				if err, _ := recover().(error); !errors.Is(err, ErrTooLarge) {
+					t.Errorf("%s: expected panic with ErrTooLarge, got %v", name, err)
+				}
+			}()
+			f()
+		}()
+	}
+
+	r := NewBuilder(0)
+	r.SetMaxSize(1000)
+	n, err := r.ReadFrom(strings.NewReader(strings.Repeat("y", 5000)))
+	if n != 1000 || err != ErrTooLarge || r.Len() != 1000 {
+		t.Fatalf("ReadFrom over max: n=%d err=%v len=%d", n, err, r.Len())
+	}
+	for _, size := range []int{999, 1000} {
+		r.Reset()
+		n, err = r.ReadFrom(strings.NewReader(strings.Repeat("y", size)))
+		if n != int64(size) || err != nil {
+			t.Fatalf("ReadFrom of %d bytes within max: n=%d err=%v", size, n, err)
+		}
+	}
+
+	b.SetMaxSize(0)
+	b.WriteString(strings.Repeat("z", 100))
+	if b.Len() != 110 {
+		t.Fatalf("Len after removing max size = %d", b.Len())
+	}
+}
+
+func TestBuilderMaxSizeWithCapacity(t *testing.T) {
+	// Pooled builders and NewBuilder can have more capacity than the limit.
+	b := NewBuilder(100)
+	b.SetMaxSize(10)
+	if n, err := b.WriteString(strings.Repeat("x", 50)); n != 0 || err != ErrTooLarge {
+		t.Fatalf("WriteString: n=%d err=%v", n, err)
+	}
+	if n, err := b.Write(make([]byte, 11)); n != 0 || err != ErrTooLarge {
+		t.Fatalf("Write: n=%d err=%v", n, err)
+	}
+	b.WriteString("123456789")
+	if n, err := b.WriteRune('ж'); n != 0 || err != ErrTooLarge {
+		t.Fatalf("WriteRune: n=%d err=%v", n, err)
+	}
+	if err := b.WriteByte('0'); err != nil {
+		t.Fatalf("WriteByte up to the limit: %v", err)
+	}
+	if err := b.WriteByte('!'); err != ErrTooLarge {
+		t.Fatalf("WriteByte past the limit: %v", err)
+	}
+
+	for name, f := range map[string]func(){
+		"Grow":        func() { b.Grow(1) },
+		"WriteInt":    func() { b.WriteInt(1) },
+		"WriteFloat":  func() { b.WriteFloat(1.5, 'f', -1, 64) },
+		"WriteTime":   func() { b.WriteTime(time.Time{}, time.Kitchen) },
+		"WriteQuoted": func() { b.WriteQuoted("") },
+	} {
+		func() {
+			defer func() {
+				if err, _ := recover().(error); err != ErrTooLarge {
+					t.Errorf("%s: expected panic with ErrTooLarge, got %v", name, err)
+				}
+			}()
+			f()
+		}()
+	}
+	if b.String() != "1234567890" {
+		t.Fatalf("content changed by failed writes: %q", b.String())
+	}
+}
+
+func TestBuilderFormattedNoIntermediate(t *testing.T) {
+	s := strings.Repeat("q", 1000)
+	layout := strings.Repeat(time.RFC3339Nano+" ", 10)
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)
+	b := NewBuilder(1 << 16)
+	b.SetMaxSize(1 << 16)
+	allocs := testing.AllocsPerRun(10, func() {
+		b.Truncate(0)
+		b.WriteQuoted(s)
+		b.WriteTime(tm, layout)
+		b.WriteFloat(1e300, 'f', -1, 64)
+	})
+	if allocs != 0 {
+		t.Fatalf("expected 0 allocs, got %v", allocs)
+	}
+}
+
+func TestBuilderFormattedGrowthPolicy(t *testing.T) {
+	// Each output is longer than the size presize estimates.
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)
+	for _, tt := range []struct {
+		name  string
+		write func(b *Builder)
+		want  string
+	}{
+		{"WriteQuoted", func(b *Builder) { b.WriteQuoted("\x01\x02\x03\x04\x05\x06\x07\x08") }, strconv.Quote("\x01\x02\x03\x04\x05\x06\x07\x08")},
+		{"WriteTime", func(b *Builder) { b.WriteTime(tm, strings.Repeat("Monday", 6)) }, strings.Repeat("Thursday", 6)},
+		{"WriteFloat", func(b *Builder) { b.WriteFloat(1e100, 'f', -1, 64) }, strconv.FormatFloat(1e100, 'f', -1, 64)},
+	} {
+		b := NewBuilder(0)
+		b.SetGrowthPolicy(GrowExact)
+		b.WriteString("ab")
+		tt.write(b)
+		if b.String() != "ab"+tt.want {
+			t.Errorf("%s: got %q", tt.name, b.String())
+		}
+		if b.Cap() != b.Len() {
+			t.Errorf("%s: GrowExact left Len %d, Cap %d", tt.name, b.Len(), b.Cap())
+		}
+	}
+}
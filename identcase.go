@@ -0,0 +1,416 @@
+package strings2
+
+import (
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CaseConverter converts identifiers between naming styles such as
+// snake_case, camelCase and PascalCase. Words are split at delimiters
+// (anything that is not a letter or digit), at lower-to-upper transitions
+// ("fooBar"), and at the end of an upper-case run ("HTTPServer" is
+// "HTTP" and "Server").
+//
+// Acronyms registered with the converter are split off even when they are
+// followed directly by another upper-case word ("JSONAPIClient" is
+// "JSON", "API", "Client"), may carry a plural s ("IDs"), and are written
+// in upper case by ToCamel and ToPascal ("user_id" becomes "UserID").
+//
+// A CaseConverter is safe for concurrent use once created.
+type CaseConverter struct {
+	acronyms map[string]struct{} // upper-case form
+	maxLen   int
+}
+
+// defaultAcronyms is the acronym list used by the package-level ToSnake,
+// ToCamel, ToPascal, ToKebab, ToScreamingSnake and ToDelimited.
+var defaultAcronyms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "CSV", "DNS", "EOF", "GUID", "HTML",
+	"HTTP", "HTTPS", "ID", "IP", "JSON", "JWT", "LHS", "OS", "QPS", "RAM",
+	"RHS", "RPC", "SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP",
+	"UI", "UID", "URI", "URL", "UTF8", "UUID", "VM", "XML", "XMPP", "XSRF",
+	"XSS", "YAML",
+}
+
+var defaultCaseConverter = NewCaseConverter(defaultAcronyms...)
+
+// DefaultAcronyms returns a copy of the acronyms known to the
+// package-level ToSnake, ToCamel, ToPascal, ToKebab, ToScreamingSnake and
+// ToDelimited, for example to extend them in NewCaseConverter.
+func DefaultAcronyms() []string {
+	return slices.Clone(defaultAcronyms)
+}
+
+// NewCaseConverter returns a CaseConverter that knows the given acronyms.
+// Acronyms are matched case-insensitively; with no acronyms only the
+// built-in splitting rules apply.
+func NewCaseConverter(acronyms ...string) *CaseConverter {
+	c := &CaseConverter{acronyms: make(map[string]struct{}, len(acronyms))}
+	for _, a := range acronyms {
+		if a == "" {
+			continue
+		}
+		a = ToUpper(a)
+		c.acronyms[a] = struct{}{}
+		c.maxLen = max(c.maxLen, len(a))
+	}
+	return c
+}
+
+// ToSnake converts s to snake_case using DefaultAcronyms.
+func ToSnake(s string) string { return defaultCaseConverter.ToSnake(s) }
+
+// ToScreamingSnake converts s to SCREAMING_SNAKE_CASE using
+// DefaultAcronyms.
+func ToScreamingSnake(s string) string { return defaultCaseConverter.ToScreamingSnake(s) }
+
+// ToKebab converts s to kebab-case using DefaultAcronyms.
+func ToKebab(s string) string { return defaultCaseConverter.ToKebab(s) }
+
+// ToCamel converts s to camelCase using DefaultAcronyms.
+func ToCamel(s string) string { return defaultCaseConverter.ToCamel(s) }
+
+// ToPascal converts s to PascalCase using DefaultAcronyms.
+func ToPascal(s string) string { return defaultCaseConverter.ToPascal(s) }
+
+// ToDelimited converts s to words joined by sep, all lower case or, if
+// upper is set, all upper case, using DefaultAcronyms.
+func ToDelimited(s string, sep byte, upper bool) string {
+	return defaultCaseConverter.ToDelimited(s, sep, upper)
+}
+
+// ToSnake converts s to snake_case.
+func (c *CaseConverter) ToSnake(s string) string { return c.convert(s, '_', caseLower) }
+
+// ToScreamingSnake converts s to SCREAMING_SNAKE_CASE.
+func (c *CaseConverter) ToScreamingSnake(s string) string { return c.convert(s, '_', caseUpper) }
+
+// ToKebab converts s to kebab-case.
+func (c *CaseConverter) ToKebab(s string) string { return c.convert(s, '-', caseLower) }
+
+// ToCamel converts s to camelCase. Acronyms other than the first word are
+// written in upper case.
+func (c *CaseConverter) ToCamel(s string) string { return c.convert(s, 0, caseCamel) }
+
+// ToPascal converts s to PascalCase. Acronyms are written in upper case.
+func (c *CaseConverter) ToPascal(s string) string { return c.convert(s, 0, casePascal) }
+
+// ToDelimited converts s to words joined by sep, all lower case or, if
+// upper is set, all upper case.
+func (c *CaseConverter) ToDelimited(s string, sep byte, upper bool) string {
+	if upper {
+		return c.convert(s, sep, caseUpper)
+	}
+	return c.convert(s, sep, caseLower)
+}
+
+type identCase uint8
+
+const (
+	caseLower identCase = iota
+	caseUpper
+	caseCamel
+	casePascal
+	casePlural // acronym in upper case followed by a lower-case s
+)
+
+type runeClass uint8
+
+const (
+	classSep runeClass = iota
+	classUpper
+	classLower // and letters without case
+	classDigit
+)
+
+var asciiRuneClass = func() [utf8.RuneSelf]runeClass {
+	var table [utf8.RuneSelf]runeClass
+	for i := range table {
+		switch {
+		case 'A' <= i && i <= 'Z':
+			table[i] = classUpper
+		case 'a' <= i && i <= 'z':
+			table[i] = classLower
+		case '0' <= i && i <= '9':
+			table[i] = classDigit
+		}
+	}
+	return table
+}()
+
+func classOf(r rune) runeClass {
+	if r < utf8.RuneSelf {
+		return asciiRuneClass[r]
+	}
+	switch {
+	case unicode.IsUpper(r) || unicode.IsTitle(r):
+		return classUpper
+	case unicode.IsLetter(r) || unicode.IsMark(r):
+		return classLower
+	case unicode.IsDigit(r):
+		return classDigit
+	}
+	return classSep
+}
+
+func decodeAt(s string, i int) (rune, int) {
+	if c := s[i]; c < utf8.RuneSelf {
+		return rune(c), 1
+	}
+	return utf8.DecodeRuneInString(s[i:])
+}
+
+// nextWord returns the bounds of the next word in s at or after pos, or
+// start == len(s) when there are no more words.
+func (c *CaseConverter) nextWord(s string, pos int) (start, end int) {
+	for pos < len(s) {
+		r, wid := decodeAt(s, pos)
+		if classOf(r) != classSep {
+			break
+		}
+		pos += wid
+	}
+	if pos == len(s) {
+		return pos, pos
+	}
+	start = pos
+
+	if n := c.acronymAt(s, start); n > 0 {
+		return start, start + n
+	}
+
+	r, wid := decodeAt(s, pos)
+	prev := classOf(r)
+	pos += wid
+	for pos < len(s) {
+		r, wid := decodeAt(s, pos)
+		cl := classOf(r)
+		switch cl {
+		case classSep:
+			return start, pos
+		case classUpper:
+			if prev == classLower || prev == classDigit {
+				return start, pos
+			}
+			// "HTTPServer": the last upper-case letter before a
+			// lower-case one starts the next word.
+			if prev == classUpper && pos+wid < len(s) {
+				nr, _ := decodeAt(s, pos+wid)
+				if classOf(nr) == classLower {
+					return start, pos
+				}
+			}
+		}
+		prev = cl
+		pos += wid
+	}
+	return start, pos
+}
+
+// acronymAt returns the length of the longest registered acronym at
+// s[start:] that ends a word, optionally with a plural s, or 0.
+//
+// An all-caps token, a run that starts at the beginning of s or after a
+// separator and reaches a separator or the end of s, is one word, so
+// "MAX_IDLE_CONNS" keeps "IDLE". Acronyms are split off any other run, as
+// in "JSONAPIClient" or "getHTTPSURL".
+func (c *CaseConverter) acronymAt(s string, start int) int {
+	if c.maxLen == 0 {
+		return 0
+	}
+	// Only ASCII upper case letters and digits can be part of an acronym.
+	run := 0
+	for start+run < len(s) {
+		cl := asciiClass(s[start+run])
+		if cl != classUpper && cl != classDigit {
+			break
+		}
+		run++
+	}
+	if (start == 0 || asciiClass(s[start-1]) == classSep) &&
+		(start+run == len(s) || asciiClass(s[start+run]) == classSep) {
+		return 0
+	}
+	for n := min(run, c.maxLen); n >= 2; n-- {
+		if _, ok := c.acronyms[s[start:start+n]]; !ok {
+			continue
+		}
+		end := start + n
+		if end == len(s) {
+			return n
+		}
+		switch asciiClass(s[end]) {
+		case classSep, classUpper, classDigit:
+			return n
+		}
+		// Plural: "IDs", "URLs".
+		if s[end] == 's' && (end+1 == len(s) || asciiClass(s[end+1]) != classLower) {
+			return n + 1
+		}
+	}
+	return 0
+}
+
+// asciiClass is classOf for a single byte; bytes of multi-byte runes are
+// reported as lower-case so they never end an acronym.
+func asciiClass(b byte) runeClass {
+	if b >= utf8.RuneSelf {
+		return classLower
+	}
+	return asciiRuneClass[b]
+}
+
+// isAcronym reports whether word is a registered acronym, in any case.
+func (c *CaseConverter) isAcronym(word string) bool {
+	if len(word) > c.maxLen {
+		return false
+	}
+	var buf [32]byte
+	if len(word) > len(buf) {
+		return false
+	}
+	for i := 0; i < len(word); i++ {
+		buf[i] = upperTable[word[i]]
+	}
+	_, ok := c.acronyms[string(buf[:len(word)])]
+	return ok
+}
+
+// wordCase returns how the word with the given index is written in mode.
+func (c *CaseConverter) wordCase(word string, index int, mode identCase) identCase {
+	switch mode {
+	case caseCamel:
+		if index == 0 {
+			return caseLower
+		}
+		fallthrough
+	case casePascal:
+		if c.isAcronym(word) {
+			return caseUpper
+		}
+		if n := len(word) - 1; n >= 2 && lowerTable[word[n]] == 's' && c.isAcronym(word[:n]) {
+			return casePlural
+		}
+		return casePascal
+	}
+	return mode
+}
+
+func (c *CaseConverter) convert(s string, sep byte, mode identCase) string {
+	isASCII := true
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			isASCII = false
+			break
+		}
+	}
+
+	// First pass: size the result and check whether s is already in the
+	// requested style, in which case it is returned as is.
+	size, words := 0, 0
+	same := true
+	prevEnd := 0
+	for pos := 0; ; {
+		start, end := c.nextWord(s, pos)
+		if start == end {
+			if prevEnd != len(s) {
+				same = false
+			}
+			break
+		}
+		word := s[start:end]
+		if words > 0 && sep != 0 {
+			size++
+			same = same && start == prevEnd+1 && s[prevEnd] == sep
+		} else {
+			same = same && start == prevEnd
+		}
+		size += len(word)
+		same = same && isASCII && wordHasCase(word, c.wordCase(word, words, mode))
+		words++
+		prevEnd, pos = end, end
+	}
+	if same {
+		return s //zerocalloc
+	}
+	if words == 0 {
+		return ""
+	}
+
+	if !isASCII {
+		// Case mapping can change the encoded length.
+		size += size >> 2
+	}
+	b := NewBuilder(size)
+	words = 0
+	for pos := 0; ; {
+		start, end := c.nextWord(s, pos)
+		if start == end {
+			break
+		}
+		if words > 0 && sep != 0 {
+			b.WriteByte(sep)
+		}
+		word := s[start:end]
+		writeWordCase(b, word, c.wordCase(word, words, mode))
+		words++
+		pos = end
+	}
+	return b.String()
+}
+
+// wordHasCase reports whether the ASCII word is already written in mode.
+func wordHasCase(word string, mode identCase) bool {
+	for i := 0; i < len(word); i++ {
+		c := word[i]
+		want := lowerTable[c]
+		if wordCharUpper(mode, i, len(word)) {
+			want = upperTable[c]
+		}
+		if c != want {
+			return false
+		}
+	}
+	return true
+}
+
+// wordCharUpper reports whether the byte at index i of a word of length n
+// is written in upper case.
+func wordCharUpper(mode identCase, i, n int) bool {
+	switch mode {
+	case caseUpper:
+		return true
+	case casePascal:
+		return i == 0
+	case casePlural:
+		return i < n-1
+	}
+	return false
+}
+
+func writeWordCase(b *Builder, word string, mode identCase) {
+	for i := 0; i < len(word); {
+		c := word[i]
+		upper := wordCharUpper(mode, i, len(word))
+		if c < utf8.RuneSelf {
+			if upper {
+				b.WriteByte(upperTable[c])
+			} else {
+				b.WriteByte(lowerTable[c])
+			}
+			i++
+			continue
+		}
+		r, wid := utf8.DecodeRuneInString(word[i:])
+		switch {
+		case mode == casePascal && i == 0:
+			b.WriteRune(unicode.ToTitle(r))
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+		default:
+			b.WriteRune(unicode.ToLower(r))
+		}
+		i += wid
+	}
+}
@@ -0,0 +1,121 @@
+package strings2
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestIdentCase(t *testing.T) {
+	tests := []struct {
+		in, snake, kebab, screaming, camel, pascal string
+	}{
+		{"", "", "", "", "", ""},
+		{"hello", "hello", "hello", "HELLO", "hello", "Hello"},
+		{"HelloWorld", "hello_world", "hello-world", "HELLO_WORLD", "helloWorld", "HelloWorld"},
+		{"helloWorld", "hello_world", "hello-world", "HELLO_WORLD", "helloWorld", "HelloWorld"},
+		{"hello_world", "hello_world", "hello-world", "HELLO_WORLD", "helloWorld", "HelloWorld"},
+		{"hello-world", "hello_world", "hello-world", "HELLO_WORLD", "helloWorld", "HelloWorld"},
+		{"Hello World", "hello_world", "hello-world", "HELLO_WORLD", "helloWorld", "HelloWorld"},
+		{"HELLO_WORLD", "hello_world", "hello-world", "HELLO_WORLD", "helloWorld", "HelloWorld"},
+		{"  __hello__world__ ", "hello_world", "hello-world", "HELLO_WORLD", "helloWorld", "HelloWorld"},
+		{"HTTPServer", "http_server", "http-server", "HTTP_SERVER", "httpServer", "HTTPServer"},
+		{"userID", "user_id", "user-id", "USER_ID", "userID", "UserID"},
+		{"user_id", "user_id", "user-id", "USER_ID", "userID", "UserID"},
+		{"UserIDs", "user_ids", "user-ids", "USER_IDS", "userIDs", "UserIDs"},
+		{"IDToken", "id_token", "id-token", "ID_TOKEN", "idToken", "IDToken"},
+		{"JSONAPIClient", "json_api_client", "json-api-client", "JSON_API_CLIENT", "jsonAPIClient", "JSONAPIClient"},
+		{"parseURLs", "parse_urls", "parse-urls", "PARSE_URLS", "parseURLs", "ParseURLs"},
+		{"utf8Decoder", "utf8_decoder", "utf8-decoder", "UTF8_DECODER", "utf8Decoder", "UTF8Decoder"},
+		{"version2Beta", "version2_beta", "version2-beta", "VERSION2_BETA", "version2Beta", "Version2Beta"},
+		{"getHTTPSUrl", "get_https_url", "get-https-url", "GET_HTTPS_URL", "getHTTPSURL", "GetHTTPSURL"},
+		{"Identity", "identity", "identity", "IDENTITY", "identity", "Identity"},
+		{"ÄpfelUndBirnen", "äpfel_und_birnen", "äpfel-und-birnen", "ÄPFEL_UND_BIRNEN", "äpfelUndBirnen", "ÄpfelUndBirnen"},
+		{"MAX_IDLE_CONNS", "max_idle_conns", "max-idle-conns", "MAX_IDLE_CONNS", "maxIdleConns", "MaxIdleConns"},
+		{"OSCAR_NAME", "oscar_name", "oscar-name", "OSCAR_NAME", "oscarName", "OscarName"},
+		{"USER_IDENTITY", "user_identity", "user-identity", "USER_IDENTITY", "userIdentity", "UserIdentity"},
+		{"USER_IDS", "user_ids", "user-ids", "USER_IDS", "userIDs", "UserIDs"},
+		{"ID_TOKEN", "id_token", "id-token", "ID_TOKEN", "idToken", "IDToken"},
+		{"OS_NAME", "os_name", "os-name", "OS_NAME", "osName", "OSName"},
+		{"IP_ADDRESS", "ip_address", "ip-address", "IP_ADDRESS", "ipAddress", "IPAddress"},
+		{"привет_мир", "привет_мир", "привет-мир", "ПРИВЕТ_МИР", "приветМир", "ПриветМир"},
+		{"userIDURL", "user_id_url", "user-id-url", "USER_ID_URL", "userIDURL", "UserIDURL"},
+		{"parseJSONAPI", "parse_json_api", "parse-json-api", "PARSE_JSON_API", "parseJSONAPI", "ParseJSONAPI"},
+		{"parseJSONAPIClient", "parse_json_api_client", "parse-json-api-client", "PARSE_JSON_API_CLIENT", "parseJSONAPIClient", "ParseJSONAPIClient"},
+	}
+	for _, tt := range tests {
+		check := func(name, got, want string) {
+			t.Helper()
+			if got != want {
+				t.Errorf("%s(%q) = %q, want %q", name, tt.in, got, want)
+			}
+		}
+		check("ToSnake", ToSnake(tt.in), tt.snake)
+		check("ToKebab", ToKebab(tt.in), tt.kebab)
+		check("ToScreamingSnake", ToScreamingSnake(tt.in), tt.screaming)
+		check("ToCamel", ToCamel(tt.in), tt.camel)
+		check("ToPascal", ToPascal(tt.in), tt.pascal)
+		check("ToSnake(ToCamel)", ToSnake(tt.camel), tt.snake)
+		check("ToSnake(ToPascal)", ToSnake(tt.pascal), tt.snake)
+	}
+}
+
+func TestToDelimited(t *testing.T) {
+	if got, want := ToDelimited("HTTPServerConfig", '.', false), "http.server.config"; got != want {
+		t.Fatalf("ToDelimited = %q, want %q", got, want)
+	}
+	if got, want := ToDelimited("httpServerConfig", ':', true), "HTTP:SERVER:CONFIG"; got != want {
+		t.Fatalf("ToDelimited = %q, want %q", got, want)
+	}
+}
+
+func TestDefaultAcronyms(t *testing.T) {
+	a := DefaultAcronyms()
+	a[0] = "CHANGED"
+	if DefaultAcronyms()[0] == "CHANGED" {
+		t.Fatal("DefaultAcronyms must return a copy")
+	}
+}
+
+func TestCaseConverterAcronyms(t *testing.T) {
+	plain := NewCaseConverter()
+	if got, want := plain.ToPascal("user_id"), "UserId"; got != want {
+		t.Fatalf("no acronyms: ToPascal = %q, want %q", got, want)
+	}
+	if got, want := plain.ToSnake("JSONAPIClient"), "jsonapi_client"; got != want {
+		t.Fatalf("no acronyms: ToSnake = %q, want %q", got, want)
+	}
+
+	custom := NewCaseConverter("gRPC", "k8s")
+	if got, want := custom.ToPascal("grpc_k8s_client"), "GRPCK8SClient"; got != want {
+		t.Fatalf("custom: ToPascal = %q, want %q", got, want)
+	}
+	if got, want := custom.ToSnake("GRPCK8SClient"), "grpc_k8s_client"; got != want {
+		t.Fatalf("custom: ToSnake = %q, want %q", got, want)
+	}
+}
+
+func TestIdentCaseNoAlloc(t *testing.T) {
+	s := "already_snake_case"
+	if got := ToSnake(s); unsafe.StringData(got) != unsafe.StringData(s) {
+		t.Fatal("expected the input string to be returned unchanged")
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		ToSnake("already_snake_case")
+		ToCamel("alreadyCamelCase")
+		ToPascal("HTTPServerID")
+	})
+	if allocs != 0 {
+		t.Fatalf("expected 0 allocs, got %v", allocs)
+	}
+	allocs = testing.AllocsPerRun(10, func() { ToSnake("HTTPServerConfig") })
+	if allocs != 1 {
+		t.Fatalf("expected 1 alloc, got %v", allocs)
+	}
+}
+
+func BenchmarkToSnake(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		ToSnake("JSONAPIClientHTTPServerConfigurationID")
+	}
+}
@@ -0,0 +1,161 @@
+package strings2
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// IndentBuilder is a Builder for generated source code and other
+// indented text. Every line written to it, by any write method, starts
+// with the current indentation; blank lines are left empty so the output
+// has no trailing whitespace.
+//
+// The indentation is the unit repeated once per level. It is obtained
+// from Repeat, so for runs of spaces or tabs it is a slice of a constant
+// and changing the level never allocates.
+//
+// The zero value is ready to use and indents by a tab per level.
+type IndentBuilder struct {
+	b       Builder
+	unit    string // "" means a tab
+	indent  string
+	depth   int
+	midLine bool
+}
+
+var (
+	_ io.Writer       = (*IndentBuilder)(nil)
+	_ io.ByteWriter   = (*IndentBuilder)(nil)
+	_ io.StringWriter = (*IndentBuilder)(nil)
+	_ io.WriterTo     = (*IndentBuilder)(nil)
+)
+
+// NewIndentBuilder returns an IndentBuilder with preallocated cap that
+// indents by unit per level, or by a tab if unit is empty.
+func NewIndentBuilder(unit string, cap int) *IndentBuilder {
+	ib := &IndentBuilder{unit: unit}
+	ib.b.init(cap)
+	return ib
+}
+
+func (b *IndentBuilder) setDepth(depth int) {
+	unit := b.unit
+	if unit == "" {
+		unit = "\t"
+	}
+	b.depth = depth
+	b.indent = Repeat(unit, depth)
+}
+
+// Indent increases the indentation by one level, starting with the next
+// line.
+func (b *IndentBuilder) Indent() {
+	b.setDepth(b.depth + 1)
+}
+
+// Dedent decreases the indentation by one level, starting with the next
+// line. It panics if the indentation is already zero.
+func (b *IndentBuilder) Dedent() {
+	if b.depth == 0 {
+		panic("strings2.IndentBuilder.Dedent: negative indentation")
+	}
+	b.setDepth(b.depth - 1)
+}
+
+// Depth returns the current indentation level.
+func (b *IndentBuilder) Depth() int { return b.depth }
+
+// WriteString appends s, indenting every line of it that is not empty.
+// It returns the length of s and a nil error.
+func (b *IndentBuilder) WriteString(s string) (int, error) {
+	n := len(s)
+	for len(s) > 0 {
+		if !b.midLine && s[0] != '\n' {
+			b.b.WriteString(b.indent)
+			b.midLine = true
+		}
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			b.b.WriteString(s)
+			break
+		}
+		b.b.WriteString(s[:i+1])
+		b.midLine = false
+		s = s[i+1:]
+	}
+	return n, nil
+}
+
+// Write appends the contents of p like WriteString.
+// Write always returns len(p), nil.
+func (b *IndentBuilder) Write(p []byte) (int, error) {
+	return b.WriteString(unsafeString(p))
+}
+
+// WriteByte appends the byte c, indenting first at the start of a line.
+// The returned error is always nil.
+func (b *IndentBuilder) WriteByte(c byte) error {
+	if c == '\n' {
+		b.midLine = false
+	} else if !b.midLine {
+		b.b.WriteString(b.indent)
+		b.midLine = true
+	}
+	return b.b.WriteByte(c)
+}
+
+// WriteRune appends the UTF-8 encoding of r, indenting first at the start
+// of a line. It returns the length of r and a nil error.
+func (b *IndentBuilder) WriteRune(r rune) (int, error) {
+	if r == '\n' {
+		return 1, b.WriteByte('\n')
+	}
+	if !b.midLine {
+		b.b.WriteString(b.indent)
+		b.midLine = true
+	}
+	return b.b.WriteRune(r)
+}
+
+// Line appends s and a newline. A partial line written before is ended
+// first, so Line always produces whole lines.
+func (b *IndentBuilder) Line(s string) {
+	if b.midLine {
+		b.b.WriteByte('\n')
+		b.midLine = false
+	}
+	b.WriteString(s)
+	b.b.WriteByte('\n')
+	b.midLine = false
+}
+
+// Linef formats according to a format specifier and appends the result
+// as by Line.
+func (b *IndentBuilder) Linef(format string, args ...any) {
+	if b.midLine {
+		b.b.WriteByte('\n')
+		b.midLine = false
+	}
+	fmt.Fprintf(b, format, args...)
+	b.b.WriteByte('\n')
+	b.midLine = false
+}
+
+// String returns the accumulated text.
+func (b *IndentBuilder) String() string { return b.b.String() }
+
+// Len returns the number of accumulated bytes.
+func (b *IndentBuilder) Len() int { return b.b.Len() }
+
+// WriteTo writes the accumulated text to w; see Builder.WriteTo.
+func (b *IndentBuilder) WriteTo(w io.Writer) (int64, error) { return b.b.WriteTo(w) }
+
+// Reset empties the builder and sets the indentation back to zero,
+// keeping the buffer.
+func (b *IndentBuilder) Reset() {
+	b.b.Truncate(0)
+	b.depth = 0
+	b.indent = ""
+	b.midLine = false
+}
@@ -0,0 +1,101 @@
+package strings2
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIndentBuilder(t *testing.T) {
+	b := NewIndentBuilder("", 0)
+	b.Line("package main")
+	b.Line("")
+	b.Line("func main() {")
+	b.Indent()
+	b.Linef("for i := 0; i < %d; i++ {", 3)
+	b.Indent()
+	b.WriteString("fmt.Println(i)\n\nprintln(")
+	b.WriteByte('i')
+	b.WriteRune(')')
+	b.Line("// trailing")
+	fmt.Fprintf(b, "a()\nb()\n")
+	b.Dedent()
+	b.Line("}")
+	b.Dedent()
+	b.Line("}")
+
+	want := "package main\n" +
+		"\n" +
+		"func main() {\n" +
+		"\tfor i := 0; i < 3; i++ {\n" +
+		"\t\tfmt.Println(i)\n" +
+		"\n" +
+		"\t\tprintln(i)\n" +
+		"\t\t// trailing\n" +
+		"\t\ta()\n" +
+		"\t\tb()\n" +
+		"\t}\n" +
+		"}\n"
+	if got := b.String(); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic on Dedent at zero")
+		}
+	}()
+	b.Dedent()
+}
+
+func TestIndentBuilderUnit(t *testing.T) {
+	b := NewIndentBuilder("  ", 64)
+	b.Line("a:")
+	b.Indent()
+	b.Linef("b: %d", 1)
+	b.Indent()
+	b.Line("- c\n- d")
+	b.Reset()
+	b.Line("x")
+	if got, want := b.String(), "x\n"; got != want {
+		t.Fatalf("after Reset: %q, want %q", got, want)
+	}
+
+	b.Indent()
+	b.Indent()
+	if b.Depth() != 2 {
+		t.Fatalf("Depth = %d", b.Depth())
+	}
+	b.Line("y")
+	if got, want := b.String(), "x\n    y\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestIndentBuilderZero(t *testing.T) {
+	var b IndentBuilder
+	b.Indent()
+	b.Line("x")
+	b.Dedent()
+	b.WriteString("y")
+	if got := b.String(); got != "\tx\ny" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestIndentBuilderNoAlloc(t *testing.T) {
+	for _, unit := range []string{"\t", "    "} {
+		b := NewIndentBuilder(unit, 1<<16)
+		allocs := testing.AllocsPerRun(100, func() {
+			b.Indent()
+			b.Line("{")
+			b.Indent()
+			b.Line("x = 1")
+			b.Dedent()
+			b.Line("}")
+			b.Dedent()
+		})
+		if allocs != 0 {
+			t.Fatalf("unit %q: expected 0 allocs, got %v", unit, allocs)
+		}
+	}
+}
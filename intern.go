@@ -0,0 +1,221 @@
+package strings2
+
+import (
+	"hash/maphash"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Interner deduplicates strings: Intern returns one canonical copy for
+// every distinct value, so repeated keys parsed from JSON, metric labels
+// and similar input share memory instead of keeping duplicates alive.
+//
+// Canonical strings are private copies, so interning a substring of a
+// large buffer does not keep the buffer alive. Lookups of values already
+// present do not allocate, even from a []byte.
+//
+// An Interner is not safe for concurrent use; see ShardedInterner.
+type Interner struct {
+	m          map[string]string
+	maxEntries int
+	maxLen     int
+	total      *atomic.Int64 // entries of all shards of a ShardedInterner, or nil
+	stats      InternStats
+}
+
+// InternStats reports the activity of an Interner.
+type InternStats struct {
+	Hits     uint64 // lookups that found a canonical string
+	Misses   uint64 // lookups that added one
+	Rejected uint64 // lookups of values too long or after the table was full
+	Entries  int    // distinct strings held
+	Bytes    int    // total length of the strings held
+}
+
+// NewInterner returns an Interner that holds at most maxEntries strings of
+// at most maxLen bytes each. A limit <= 0 means no limit. Values over the
+// limits are returned without being interned.
+func NewInterner(maxEntries, maxLen int) *Interner {
+	return &Interner{
+		m:          make(map[string]string),
+		maxEntries: maxEntries,
+		maxLen:     maxLen,
+	}
+}
+
+// Intern returns the canonical string equal to s, adding a copy of s to
+// the table if it is not present. If s cannot be added because of the
+// limits, s itself is returned.
+func (in *Interner) Intern(s string) string {
+	if c, ok := in.m[s]; ok {
+		in.stats.Hits++
+		return c
+	}
+	if !in.admit(len(s)) {
+		return s
+	}
+	c := strings.Clone(s)
+	in.add(c)
+	return c
+}
+
+// InternBytes returns the canonical string equal to b. Unlike string(b),
+// it does not allocate when the value is already present. If b cannot be
+// added because of the limits, a new string is returned.
+func (in *Interner) InternBytes(b []byte) string {
+	if c, ok := in.m[unsafeString(b)]; ok {
+		in.stats.Hits++
+		return c
+	}
+	if !in.admit(len(b)) {
+		return string(b)
+	}
+	c := string(b)
+	in.add(c)
+	return c
+}
+
+// admit reports whether a value of length n may be added; if so, the
+// caller adds it.
+func (in *Interner) admit(n int) bool {
+	ok := in.maxLen <= 0 || n <= in.maxLen
+	if ok && in.maxEntries > 0 {
+		if in.total != nil {
+			ok = in.total.Add(1) <= int64(in.maxEntries)
+			if !ok {
+				in.total.Add(-1)
+			}
+		} else {
+			ok = len(in.m) < in.maxEntries
+		}
+	}
+	if !ok {
+		in.stats.Rejected++
+	}
+	return ok
+}
+
+func (in *Interner) add(c string) {
+	if in.m == nil {
+		in.m = make(map[string]string)
+	}
+	in.m[c] = c
+	in.stats.Misses++
+	in.stats.Entries++
+	in.stats.Bytes += len(c)
+}
+
+// Len returns the number of distinct strings held.
+func (in *Interner) Len() int { return len(in.m) }
+
+// Stats returns the counters of in.
+func (in *Interner) Stats() InternStats { return in.stats }
+
+// Reset removes all strings and clears the counters. Strings returned
+// before stay valid; they are just no longer canonical.
+func (in *Interner) Reset() {
+	if in.total != nil {
+		in.total.Add(-int64(len(in.m)))
+	}
+	clear(in.m)
+	in.stats = InternStats{}
+}
+
+// ShardedInterner is an Interner that is safe for concurrent use. Values
+// are spread over independently locked shards by hash, so goroutines
+// interning different values rarely contend.
+type ShardedInterner struct {
+	seed    maphash.Seed
+	shards  []internShard
+	entries atomic.Int64 // shared by the shards to enforce maxEntries
+}
+
+type internShard struct {
+	mu sync.Mutex
+	in Interner
+	_  [64]byte // keep shards on separate cache lines
+}
+
+// NewShardedInterner returns a ShardedInterner with the given number of
+// shards, or 16 if shards <= 0, holding at most maxEntries strings in
+// total, of at most maxLen bytes each.
+func NewShardedInterner(shards, maxEntries, maxLen int) *ShardedInterner {
+	if shards <= 0 {
+		shards = 16
+	}
+	s := &ShardedInterner{
+		seed:   maphash.MakeSeed(),
+		shards: make([]internShard, shards),
+	}
+	for i := range s.shards {
+		s.shards[i].in = Interner{
+			m:          make(map[string]string),
+			maxEntries: maxEntries,
+			maxLen:     maxLen,
+			total:      &s.entries,
+		}
+	}
+	return s
+}
+
+func (s *ShardedInterner) shard(v string) *internShard {
+	return &s.shards[maphash.String(s.seed, v)%uint64(len(s.shards))]
+}
+
+// Intern is Interner.Intern for concurrent use.
+func (s *ShardedInterner) Intern(v string) string {
+	sh := s.shard(v)
+	sh.mu.Lock()
+	c := sh.in.Intern(v)
+	sh.mu.Unlock()
+	return c
+}
+
+// InternBytes is Interner.InternBytes for concurrent use.
+func (s *ShardedInterner) InternBytes(b []byte) string {
+	sh := s.shard(unsafeString(b))
+	sh.mu.Lock()
+	c := sh.in.InternBytes(b)
+	sh.mu.Unlock()
+	return c
+}
+
+// Len returns the number of distinct strings held.
+func (s *ShardedInterner) Len() int {
+	n := 0
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.Lock()
+		n += sh.in.Len()
+		sh.mu.Unlock()
+	}
+	return n
+}
+
+// Stats returns the counters summed over all shards.
+func (s *ShardedInterner) Stats() InternStats {
+	var st InternStats
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.Lock()
+		x := sh.in.stats
+		sh.mu.Unlock()
+		st.Hits += x.Hits
+		st.Misses += x.Misses
+		st.Rejected += x.Rejected
+		st.Entries += x.Entries
+		st.Bytes += x.Bytes
+	}
+	return st
+}
+
+// Reset removes all strings and clears the counters of every shard.
+func (s *ShardedInterner) Reset() {
+	for i := range s.shards {
+		sh := &s.shards[i]
+		sh.mu.Lock()
+		sh.in.Reset()
+		sh.mu.Unlock()
+	}
+}
@@ -0,0 +1,124 @@
+package strings2
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+func TestInterner(t *testing.T) {
+	in := NewInterner(0, 0)
+	buf := []byte(`{"name":1,"name":2}`)
+	a := in.InternBytes(buf[2:6])
+	b := in.Intern(string(buf[11:15]))
+	if a != "name" || unsafe.StringData(a) != unsafe.StringData(b) {
+		t.Fatal("expected one canonical string")
+	}
+	if unsafe.StringData(a) == &buf[2] {
+		t.Fatal("canonical string must not alias the input")
+	}
+	buf[2] = 'X'
+	if a != "name" {
+		t.Fatal("canonical string changed with the input buffer")
+	}
+
+	st := in.Stats()
+	if st.Hits != 1 || st.Misses != 1 || st.Entries != 1 || st.Bytes != 4 || in.Len() != 1 {
+		t.Fatalf("unexpected stats %+v", st)
+	}
+
+	key := []byte("name")
+	allocs := testing.AllocsPerRun(100, func() {
+		in.InternBytes(key)
+		in.Intern("name")
+	})
+	if allocs != 0 {
+		t.Fatalf("expected 0 allocs on hit, got %v", allocs)
+	}
+
+	in.Reset()
+	if in.Len() != 0 || in.Stats() != (InternStats{}) {
+		t.Fatal("Reset left state")
+	}
+	var zero Interner
+	if zero.Intern("x") != "x" || zero.Len() != 1 {
+		t.Fatal("zero Interner not usable")
+	}
+}
+
+func TestInternerLimits(t *testing.T) {
+	in := NewInterner(2, 5)
+	long := "too long"
+	if got := in.Intern(long); unsafe.StringData(got) != unsafe.StringData(long) {
+		t.Fatal("value over maxLen should be returned as is")
+	}
+	in.Intern("a")
+	in.Intern("b")
+	in.InternBytes([]byte("c"))
+	in.Intern("a")
+	st := in.Stats()
+	if in.Len() != 2 || st.Rejected != 2 || st.Hits != 1 || st.Misses != 2 {
+		t.Fatalf("unexpected stats %+v, len %d", st, in.Len())
+	}
+}
+
+func TestShardedInterner(t *testing.T) {
+	s := NewShardedInterner(4, 0, 0)
+	var wg sync.WaitGroup
+	results := make([][]string, 8)
+	for g := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				k := fmt.Sprintf("key%d", i%100)
+				if g%2 == 0 {
+					results[g] = append(results[g], s.Intern(k))
+				} else {
+					results[g] = append(results[g], s.InternBytes([]byte(k)))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	for g := range results {
+		for i, v := range results[g] {
+			if unsafe.StringData(v) != unsafe.StringData(results[0][i]) {
+				t.Fatalf("goroutine %d got a non-canonical string for %q", g, v)
+			}
+		}
+	}
+	st := s.Stats()
+	if s.Len() != 100 || st.Entries != 100 || st.Misses != 100 || st.Hits != 8000-100 {
+		t.Fatalf("unexpected stats %+v", st)
+	}
+
+	limited := NewShardedInterner(16, 4, 0)
+	for i := range 100 {
+		limited.Intern(fmt.Sprint(i))
+	}
+	if limited.Len() != 4 || limited.Stats().Rejected != 96 {
+		t.Fatalf("Len = %d, stats %+v; want exactly the limit of 4", limited.Len(), limited.Stats())
+	}
+	limited.Reset()
+	if limited.Len() != 0 {
+		t.Fatal("Reset left strings")
+	}
+	for i := range 100 {
+		limited.Intern(fmt.Sprint("after", i))
+	}
+	if limited.Len() != 4 {
+		t.Fatalf("Len after Reset = %d, want 4", limited.Len())
+	}
+}
+
+func BenchmarkInternBytesHit(b *testing.B) {
+	in := NewInterner(0, 0)
+	key := []byte("metric_label_name")
+	in.InternBytes(key)
+	b.ReportAllocs()
+	for b.Loop() {
+		in.InternBytes(key)
+	}
+}
@@ -0,0 +1,77 @@
+package strings2
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// DefaultMaxPooledCap is the largest buffer capacity a BuilderPool created
+// with maxRetained <= 0 keeps for reuse.
+const DefaultMaxPooledCap = 64 << 10
+
+// minPooledShift is log2 of the smallest size class.
+const minPooledShift = 6
+
+// BuilderPool is a set of sync.Pools of Builders grouped into power-of-two
+// size classes (64, 128, 256, ... bytes), so a Get for a small string never
+// pins a large buffer and a Get for a large one does not have to grow a
+// small buffer repeatedly. Builders whose capacity exceeds the retention
+// limit are dropped on Put and left to the garbage collector.
+//
+// A BuilderPool is safe for concurrent use.
+type BuilderPool struct {
+	classes   []sync.Pool // classes[i] holds builders with cap >= 1<<(i+minPooledShift)
+	maxCap    int
+	zeroOnPut bool
+}
+
+// NewBuilderPool returns a pool that retains builders with a capacity of
+// at most maxRetained bytes, or DefaultMaxPooledCap if maxRetained <= 0.
+// If zeroOnPut is set, Put clears the whole buffer with memclr before the
+// builder is pooled, for builders that held secrets.
+func NewBuilderPool(maxRetained int, zeroOnPut bool) *BuilderPool {
+	if maxRetained <= 0 {
+		maxRetained = DefaultMaxPooledCap
+	}
+	maxRetained = max(maxRetained, 1<<minPooledShift)
+	n := bits.Len(uint(maxRetained)) - minPooledShift
+	return &BuilderPool{
+		classes:   make([]sync.Pool, n),
+		maxCap:    maxRetained,
+		zeroOnPut: zeroOnPut,
+	}
+}
+
+// Get returns an empty Builder with a capacity of at least sizeHint bytes.
+// Builders larger than the retention limit are allocated directly.
+func (p *BuilderPool) Get(sizeHint int) *Builder {
+	sizeHint = max(sizeHint, 1<<minPooledShift)
+	// Round up: every builder in class i can hold sizeHint bytes.
+	i := bits.Len(uint(sizeHint-1)) - minPooledShift
+	if i >= len(p.classes) {
+		return NewBuilder(sizeHint)
+	}
+	if v := p.classes[i].Get(); v != nil {
+		return v.(*Builder)
+	}
+	return NewBuilder(1 << (i + minPooledShift))
+}
+
+// Put resets b and returns it to the pool. Builders that are too small or
+// larger than the retention limit are dropped. The caller must not use b,
+// or any string it returned, after Put.
+func (p *BuilderPool) Put(b *Builder) {
+	b.copyCheck()
+	c := cap(b.buf)
+	if c < 1<<minPooledShift || c > p.maxCap {
+		return
+	}
+	b.growth, b.maxSize = nil, 0
+	if p.zeroOnPut {
+		b.ResetAndKeepCap()
+	} else {
+		b.buf = b.buf[:0]
+	}
+	// Round down: b can serve any Get from the class it is put into.
+	p.classes[bits.Len(uint(c))-1-minPooledShift].Put(b)
+}
@@ -0,0 +1,98 @@
+package strings2
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBuilderPool(t *testing.T) {
+	p := NewBuilderPool(1<<12, false)
+	for _, hint := range []int{0, 1, 63, 64, 65, 1000, 4096, 4097, 1 << 20} {
+		b := p.Get(hint)
+		if b.Len() != 0 || b.Cap() < hint {
+			t.Fatalf("Get(%d): len=%d cap=%d", hint, b.Len(), b.Cap())
+		}
+		b.WriteString(strings.Repeat("x", hint))
+		p.Put(b)
+	}
+
+	// A pooled builder comes back empty and is reused for smaller hints of
+	// the same class. sync.Pool may drop items, so only require reuse once.
+	reused := false
+	for range 100 {
+		b := p.Get(200)
+		b.WriteString("hello")
+		p.Put(b)
+		if b2 := p.Get(129); b2 == b {
+			if b2.Len() != 0 {
+				t.Fatal("pooled builder not reset")
+			}
+			reused = true
+		}
+	}
+	if !reused {
+		t.Fatal("pool never reused a builder")
+	}
+}
+
+func TestBuilderPoolMaxRetained(t *testing.T) {
+	p := NewBuilderPool(1024, false)
+	big := NewBuilder(2048)
+	p.Put(big)
+	for range 10 {
+		if p.Get(1024) == big {
+			t.Fatal("builder above the retention limit was pooled")
+		}
+	}
+	if NewBuilderPool(0, false).maxCap != DefaultMaxPooledCap {
+		t.Fatal("expected default retention limit")
+	}
+}
+
+func TestBuilderPoolZeroOnPut(t *testing.T) {
+	p := NewBuilderPool(0, true)
+	b := p.Get(100)
+	b.WriteString("secret password")
+	buf := b.Bytes()[:b.Cap()]
+	p.Put(b)
+	for i, c := range buf {
+		if c != 0 {
+			t.Fatalf("byte %d not cleared: %q", i, c)
+		}
+	}
+}
+
+func TestBuilderPoolConcurrent(t *testing.T) {
+	p := NewBuilderPool(0, false)
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			want := strings.Repeat(string(rune('a'+g)), 100+g)
+			for range 1000 {
+				b := p.Get(len(want))
+				b.WriteString(want)
+				if b.String() != want {
+					t.Error("concurrent builder corrupted")
+					return
+				}
+				p.Put(b)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkBuilderPool(b *testing.B) {
+	p := NewBuilderPool(0, false)
+	b.ReportAllocs()
+	for b.Loop() {
+		sb := p.Get(256)
+		sb.WriteString("GET /index.html HTTP/1.1\r\n")
+		sb.WriteInt(12345)
+		_ = sb.String()
+		p.Put(sb)
+	}
+}
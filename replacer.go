@@ -0,0 +1,435 @@
+package strings2
+
+import "unicode/utf8"
+
+// Replacer replaces a list of strings with replacements in a single pass.
+// It is safe for concurrent use by multiple goroutines.
+//
+// Like [ReplaceString], Replace returns s itself when nothing matched, and
+// when no replacement is longer than the string it replaces the result is
+// built by shrinking one copy of s in place.
+type Replacer struct {
+	olds []string
+	news []string
+
+	// shrink reports that no replacement is longer than its old string,
+	// so the result always fits into len(s) bytes.
+	shrink bool
+
+	// byte fast path: every old string is exactly one byte long.
+	byteIdx  *[256]int32 // replacement index + 1, 0 means no match
+	byteOnly bool        // every new string is exactly one byte long too
+
+	// generic path: Aho-Corasick automaton over the old strings.
+	ac *acMatcher
+}
+
+// NewReplacer returns a new [Replacer] from a list of old, new string
+// pairs. Replacements are performed in the order they appear in the
+// target string, without overlapping matches, and comparisons are done
+// in argument order, so the first matching old string wins.
+//
+// Unlike strings.NewReplacer, an empty old string matches before every
+// rune rather than every byte, the same as [ReplaceString].
+//
+// NewReplacer panics if given an odd number of arguments.
+func NewReplacer(oldnew ...string) *Replacer {
+	if len(oldnew)%2 == 1 {
+		panic("strings2.NewReplacer: odd argument count")
+	}
+	r := &Replacer{
+		olds:   make([]string, 0, len(oldnew)/2),
+		news:   make([]string, 0, len(oldnew)/2),
+		shrink: true,
+	}
+
+	allOneByte := len(oldnew) > 0
+	for i := 0; i < len(oldnew); i += 2 {
+		old, new := oldnew[i], oldnew[i+1]
+		r.olds = append(r.olds, old)
+		r.news = append(r.news, new)
+		if len(new) > len(old) {
+			r.shrink = false
+		}
+		if len(old) != 1 {
+			allOneByte = false
+		}
+	}
+
+	if allOneByte {
+		var table [256]int32
+		r.byteOnly = true
+		// Walk backwards so the first pair for a given byte wins.
+		for i := len(r.olds) - 1; i >= 0; i-- {
+			table[r.olds[i][0]] = int32(i + 1)
+		}
+		for i := range r.news {
+			if table[r.olds[i][0]] == int32(i+1) && len(r.news[i]) != 1 {
+				r.byteOnly = false
+			}
+		}
+		r.byteIdx = &table
+		return r
+	}
+
+	r.ac = newACMatcher(r.olds)
+	return r
+}
+
+// Replace returns a copy of s with all replacements performed.
+// If no old string occurs in s, s itself is returned.
+func (r *Replacer) Replace(s string) string {
+	if len(s) == 0 && (r.ac == nil || !r.ac.hasEmpty) {
+		return s
+	}
+	if r.byteIdx != nil {
+		if r.byteOnly {
+			return r.replaceByteMap(s)
+		}
+		return r.replaceByteStrings(s)
+	}
+	if r.shrink {
+		return r.replaceShrink(s)
+	}
+	return r.replaceGrow(s)
+}
+
+// replaceByteMap maps single bytes to single bytes: one lazy copy, no
+// length change.
+func (r *Replacer) replaceByteMap(s string) string {
+	table := r.byteIdx
+	var sb []byte // lazy modifiable copy
+	for i := 0; i < len(s); i++ {
+		idx := table[s[i]]
+		if idx == 0 {
+			continue
+		}
+		if sb == nil {
+			sb = make([]byte, len(s))
+			copy(sb, s)
+		}
+		sb[i] = r.news[idx-1][0]
+	}
+	if sb == nil {
+		return s //zerocalloc
+	}
+	return unsafeString(sb)
+}
+
+// replaceByteStrings maps single bytes to arbitrary strings.
+func (r *Replacer) replaceByteStrings(s string) string {
+	table := r.byteIdx
+
+	// First pass: size the result exactly, or bail out without allocating.
+	newSize := len(s)
+	found := false
+	for i := 0; i < len(s); i++ {
+		if idx := table[s[i]]; idx != 0 {
+			newSize += len(r.news[idx-1]) - 1
+			found = true
+		}
+	}
+	if !found {
+		return s
+	}
+
+	if r.shrink {
+		// In-place: the result never outgrows s.
+		sb := make([]byte, len(s))
+		copy(sb, s)
+		writePos := 0
+		for i := 0; i < len(sb); i++ {
+			idx := table[sb[i]]
+			if idx == 0 {
+				sb[writePos] = sb[i]
+				writePos++
+				continue
+			}
+			writePos += copy(sb[writePos:], r.news[idx-1])
+		}
+		return unsafeString(sb[:writePos])
+	}
+
+	result := make([]byte, newSize)
+	currPos := 0
+	prevEnd := 0
+	for i := 0; i < len(s); i++ {
+		idx := table[s[i]]
+		if idx == 0 {
+			continue
+		}
+		currPos += copy(result[currPos:], s[prevEnd:i])
+		currPos += copy(result[currPos:], r.news[idx-1])
+		prevEnd = i + 1
+	}
+	copy(result[currPos:], s[prevEnd:])
+	return unsafeString(result)
+}
+
+// replaceShrink is used when no new string is longer than its old one.
+// Like ReplaceString's delta <= 0 branch it copies s once, lazily, and
+// compacts the copy in place.
+func (r *Replacer) replaceShrink(s string) string {
+	var sb []byte // lazy modifiable copy
+
+	pos := 0
+	writePos := 0
+	skipEmpty := false
+	for pos <= len(s) {
+		start, end, idx := r.ac.next(s, pos, skipEmpty)
+		if start < 0 {
+			break
+		}
+		if sb == nil {
+			sb = make([]byte, len(s))
+			copy(sb, s)
+		}
+
+		copy(sb[writePos:], sb[pos:start])
+		writePos += start - pos
+		writePos += copy(sb[writePos:], r.news[idx])
+
+		skipEmpty = start == end
+		pos = end
+	}
+
+	if sb == nil {
+		return s //zerocalloc
+	}
+
+	if pos < len(s) {
+		writePos += copy(sb[writePos:], sb[pos:])
+	}
+	return unsafeString(sb[:writePos])
+}
+
+// replaceGrow is used when some new string is longer than its old one.
+// The result is appended to a lazily allocated, non-zeroed buffer with
+// some headroom over len(s), so the automaton runs only once.
+func (r *Replacer) replaceGrow(s string) string {
+	var buf []byte // lazy result
+
+	pos := 0
+	skipEmpty := false
+	for pos <= len(s) {
+		start, end, idx := r.ac.next(s, pos, skipEmpty)
+		if start < 0 {
+			break
+		}
+		if buf == nil {
+			buf = MakeNoZeroCap(0, len(s)+len(s)>>2+len(r.news[idx]))
+		}
+		buf = append(buf, s[pos:start]...)
+		buf = append(buf, r.news[idx]...)
+
+		skipEmpty = start == end
+		pos = end
+	}
+	if buf == nil {
+		return s
+	}
+	if pos < len(s) {
+		buf = append(buf, s[pos:]...)
+	}
+
+	return unsafeString(buf)
+}
+
+// acMatcher is a leftmost-first Aho-Corasick automaton. The goto and
+// failure functions are flattened into a dense DFA over the byte classes
+// that occur in the patterns.
+type acMatcher struct {
+	class  [256]uint16 // byte -> column; 0 is "byte not in any pattern"
+	first  [256]bool   // byte starts some non-empty pattern
+	stride int
+
+	trans []int32 // trie goto, -1 for missing; later the full DFA
+	depth []int32
+	match []int32 // best (lowest) pattern index ending exactly here, -1 if none
+	dict  []int32 // nearest proper suffix with match >= 0, 0 if none
+	trie  []int32 // copy of the pure trie edges, used by lookup
+
+	hasEmpty bool
+	emptyIdx int
+}
+
+func newACMatcher(patterns []string) *acMatcher {
+	m := &acMatcher{emptyIdx: -1}
+
+	ncls := 0
+	for _, p := range patterns {
+		for i := 0; i < len(p); i++ {
+			if m.class[p[i]] == 0 {
+				ncls++
+				m.class[p[i]] = uint16(ncls)
+			}
+		}
+	}
+	m.stride = ncls + 1
+
+	m.addNode(0)
+	for idx, p := range patterns {
+		if len(p) == 0 {
+			if !m.hasEmpty {
+				m.hasEmpty = true
+				m.emptyIdx = idx
+			}
+			continue
+		}
+		m.first[p[0]] = true
+		node := int32(0)
+		for i := 0; i < len(p); i++ {
+			c := int(m.class[p[i]])
+			next := m.trans[int(node)*m.stride+c]
+			if next < 0 {
+				next = m.addNode(m.depth[node] + 1)
+				m.trans[int(node)*m.stride+c] = next
+			}
+			node = next
+		}
+		if m.match[node] < 0 {
+			m.match[node] = int32(idx)
+		}
+	}
+
+	m.trie = append([]int32(nil), m.trans...)
+	m.build()
+	return m
+}
+
+func (m *acMatcher) addNode(depth int32) int32 {
+	id := int32(len(m.depth))
+	m.depth = append(m.depth, depth)
+	m.match = append(m.match, -1)
+	m.dict = append(m.dict, 0)
+	for i := 0; i < m.stride; i++ {
+		m.trans = append(m.trans, -1)
+	}
+	return id
+}
+
+// build computes failure links breadth first and folds them into trans.
+func (m *acMatcher) build() {
+	fail := make([]int32, len(m.depth))
+	queue := make([]int32, 0, len(m.depth))
+
+	for c := 0; c < m.stride; c++ {
+		next := m.trans[c]
+		if next <= 0 {
+			m.trans[c] = 0
+			continue
+		}
+		fail[next] = 0
+		queue = append(queue, next)
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		f := fail[node]
+		if m.match[f] >= 0 {
+			m.dict[node] = f
+		} else {
+			m.dict[node] = m.dict[f]
+		}
+
+		base := int(node) * m.stride
+		fbase := int(f) * m.stride
+		for c := 0; c < m.stride; c++ {
+			next := m.trans[base+c]
+			if next < 0 {
+				m.trans[base+c] = m.trans[fbase+c]
+				continue
+			}
+			fail[next] = m.trans[fbase+c]
+			queue = append(queue, next)
+		}
+	}
+}
+
+// next returns the leftmost match in s starting at or after pos; among
+// matches with the same start the earliest pattern wins. start is -1 if
+// there is no match. If skipEmpty is set, the empty pattern is not
+// allowed to match at pos itself.
+func (m *acMatcher) next(s string, pos int, skipEmpty bool) (start, end, idx int) {
+	if m.hasEmpty {
+		return m.nextWithEmpty(s, pos, skipEmpty)
+	}
+
+	candStart, candEnd, candIdx := -1, 0, 0
+	state := int32(0)
+	for i := pos; i < len(s); i++ {
+		if state == 0 {
+			// Skip bytes that cannot start a match.
+			for i < len(s) && !m.first[s[i]] {
+				i++
+			}
+			if i == len(s) {
+				break
+			}
+		}
+		state = m.trans[int(state)*m.stride+int(m.class[s[i]])]
+
+		n := state
+		if m.match[n] < 0 {
+			n = m.dict[n]
+		}
+		for n > 0 {
+			st := i + 1 - int(m.depth[n])
+			id := int(m.match[n])
+			if candStart < 0 || st < candStart || (st == candStart && id < candIdx) {
+				candStart, candEnd, candIdx = st, i+1, id
+			}
+			n = m.dict[n]
+		}
+
+		// No match that is still in progress can start at or before the
+		// candidate, so it is final.
+		if candStart >= 0 && i+1-int(m.depth[state]) > candStart {
+			break
+		}
+	}
+	return candStart, candEnd, candIdx
+}
+
+// nextWithEmpty mirrors the per-position trie lookup of strings.Replacer,
+// needed because the empty pattern matches everywhere and competes by
+// priority with longer matches at the same position.
+func (m *acMatcher) nextWithEmpty(s string, pos int, skipEmpty bool) (start, end, idx int) {
+	for i := pos; i <= len(s); {
+		if id, n, ok := m.lookup(s[i:], skipEmpty && i == pos); ok {
+			return i, i + n, id
+		}
+		if i == len(s) {
+			break
+		}
+		_, wid := utf8.DecodeRuneInString(s[i:])
+		i += wid
+	}
+	return -1, 0, 0
+}
+
+// lookup walks the trie along s and returns the best priority match at
+// the start of s.
+func (m *acMatcher) lookup(s string, ignoreRoot bool) (idx, keylen int, ok bool) {
+	idx = -1
+	if !ignoreRoot && m.hasEmpty {
+		idx, keylen, ok = m.emptyIdx, 0, true
+	}
+	node := int32(0)
+	for i := 0; i < len(s); i++ {
+		c := m.class[s[i]]
+		if c == 0 {
+			break
+		}
+		node = m.trie[int(node)*m.stride+int(c)]
+		if node < 0 {
+			break
+		}
+		if id := int(m.match[node]); id >= 0 && (idx < 0 || id < idx) {
+			idx, keylen, ok = id, i+1, true
+		}
+	}
+	return idx, keylen, ok
+}
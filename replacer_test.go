@@ -0,0 +1,165 @@
+package strings2
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestReplacer(t *testing.T) {
+	tests := []struct {
+		name   string
+		oldnew []string
+		s      string
+	}{
+		{"no pairs", nil, "hello"},
+		{"single pattern", []string{"hello", "hi"}, "hello hello world"},
+		{"byte map", []string{"a", "1", "b", "2", "c", "3"}, "abcabc xyz"},
+		{"byte map no match", []string{"a", "1"}, "xyz"},
+		{"byte to string", []string{"<", "&lt;", ">", "&gt;", "&", "&amp;"}, "<a href=\"x\">&</a>"},
+		{"byte to shorter", []string{"\n", "", "\r", ""}, "line1\r\nline2\r\n"},
+		{"byte duplicate first wins", []string{"a", "1", "a", "2"}, "banana"},
+		{"html escaper", []string{"&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&#34;", "'", "&#39;"}, `<b>"it's" & more</b>`},
+		{"first pattern wins", []string{"a", "A", "aa", "B"}, "aaaa"},
+		{"longer listed first", []string{"aa", "B", "a", "A"}, "aaaaa"},
+		{"prefix overlap", []string{"abc", "1", "bcd", "2"}, "abcd bcd abcd"},
+		{"suffix overlap", []string{"bcd", "2", "abc", "1"}, "abcd"},
+		{"leftmost beats priority", []string{"bc", "X", "abcd", "Y"}, "abcbc"},
+		{"nested", []string{"he", "1", "she", "2", "his", "3", "hers", "4"}, "ushers and his shells"},
+		{"shrink", []string{"hello", "hi", "world", "w"}, "hello world, hello everyone"},
+		{"grow", []string{"a", "apple", "b", "banana"}, "a b c a b c"},
+		{"mixed grow shrink", []string{"cat", "dog", "mouse", "rat", "x", "xylophone"}, "cat and mouse and x"},
+		{"delete", []string{"foo", "", "bar", ""}, "foobarbazfoo"},
+		{"unicode", []string{"привет", "hello", "мир", "world", "😀", ":)"}, "привет мир 😀"},
+		{"chinese", []string{"你好", "您好", "世界", "宇宙"}, "你好 世界 你好"},
+		{"old equals new", []string{"a", "a", "b", "c"}, "abab"},
+		{"no match", []string{"xyz", "1", "qqq", "2"}, "abcdef"},
+		{"empty input", []string{"a", "b", "cc", "d"}, ""},
+		{"empty old", []string{"", "X"}, "abc"},
+		{"empty old first", []string{"", "X", "a", "A"}, "abc"},
+		{"empty old last", []string{"a", "A", "", "X"}, "abc"},
+		{"empty old empty input", []string{"", "X"}, ""},
+		{"empty old no new", []string{"", "", "b", "B"}, "abc"},
+		{"whole string", []string{"abcdef", "1"}, "abcdef"},
+		{"log sanitizer", []string{"\n", "\\n", "\t", "\\t", "password=", "password=***", "token=", "token=***"}, "user=bob\tpassword=hunter2\ntoken=abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewReplacer(tt.oldnew...).Replace(tt.s)
+			want := strings.NewReplacer(tt.oldnew...).Replace(tt.s)
+			if got != want {
+				t.Fatalf("\nReplacer(%q).Replace(%q)\ngot : %q\nwant: %q", tt.oldnew, tt.s, got, want)
+			}
+		})
+	}
+}
+
+func TestReplacerEmptyOldRunes(t *testing.T) {
+	got := NewReplacer("", "-").Replace("héllo")
+	want := ReplaceAll("héllo", "", "-")
+	if got != want {
+		t.Fatalf("want=%q got=%q", want, got)
+	}
+}
+
+func TestReplacerRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	alphabet := "abc"
+	word := func(maxLen int) string {
+		n := rng.Intn(maxLen) + 1
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = alphabet[rng.Intn(len(alphabet))]
+		}
+		return string(b)
+	}
+
+	for iter := 0; iter < 2000; iter++ {
+		npairs := rng.Intn(6) + 1
+		oldnew := make([]string, 0, 2*npairs)
+		for i := 0; i < npairs; i++ {
+			old, new := word(4), strings.ToUpper(word(5))
+			if rng.Intn(20) == 0 {
+				old = ""
+			}
+			oldnew = append(oldnew, old, new[:rng.Intn(len(new)+1)])
+		}
+		s := word(40)
+
+		got := NewReplacer(oldnew...).Replace(s)
+		want := strings.NewReplacer(oldnew...).Replace(s)
+		if got != want {
+			t.Fatalf("\nReplacer(%q).Replace(%q)\ngot : %q\nwant: %q", oldnew, s, got, want)
+		}
+	}
+}
+
+func TestReplacerNoMatchReturnsInput(t *testing.T) {
+	s := strings.Repeat("abc ", 100)
+	for _, r := range []*Replacer{
+		NewReplacer("x", "y"),
+		NewReplacer("x", "yy"),
+		NewReplacer("xyz", "1", "qq", "2"),
+		NewReplacer("xyz", "1234", "qq", "2"),
+	} {
+		got := r.Replace(s)
+		if unsafe.StringData(got) != unsafe.StringData(s) {
+			t.Fatal("expected the input string to be returned unchanged")
+		}
+		allocs := testing.AllocsPerRun(10, func() { r.Replace(s) })
+		if allocs != 0 {
+			t.Fatalf("expected 0 allocs, got %v", allocs)
+		}
+	}
+}
+
+func TestReplacerOddArgs(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic on odd argument count")
+		}
+	}()
+	NewReplacer("a")
+}
+
+var logLine = strings.Repeat("user=bob\tpassword=hunter2\nsession token=abc123 ip=10.0.0.1 ", 200)
+
+var sanitizePairs = []string{
+	"\n", "\\n",
+	"\t", "\\t",
+	"\r", "\\r",
+	"password=", "password=***",
+	"token=", "token=***",
+	"secret=", "secret=***",
+}
+
+func BenchmarkReplacerSanitize(b *testing.B) {
+	r := NewReplacer(sanitizePairs...)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		r.Replace(logLine)
+	}
+}
+
+func BenchmarkStringsReplacerSanitize(b *testing.B) {
+	r := strings.NewReplacer(sanitizePairs...)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		r.Replace(logLine)
+	}
+}
+
+func BenchmarkChainedReplaceAllSanitize(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		s := logLine
+		for i := 0; i < len(sanitizePairs); i += 2 {
+			s = ReplaceAll(s, sanitizePairs[i], sanitizePairs[i+1])
+		}
+	}
+}
@@ -0,0 +1,452 @@
+package strings2
+
+import (
+	"io"
+	"iter"
+	"strings"
+	"unicode/utf8"
+)
+
+// ropeMaxLeaf is the largest leaf a rope builds on its own. Adjacent small
+// leaves are merged up to this size, so a run of single-character inserts
+// does not degrade into one leaf per character.
+const ropeMaxLeaf = 1024
+
+// Rope is an immutable string stored as a height-balanced tree of string
+// leaves. Insert, Delete, Slice and concatenation take O(log n) time and
+// share all unchanged leaves with the original, so old versions remain
+// valid and cheap to keep, which suits undo histories and concurrent
+// readers. ByteAt, line and rune lookups are O(log n) as well.
+//
+// The zero Rope is empty and ready to use. Line and rune positions count
+// '\n' bytes and UTF-8 start bytes, so rune positions assume valid UTF-8.
+type Rope struct {
+	root *ropeNode
+}
+
+type ropeNode struct {
+	left, right *ropeNode // nil for a leaf
+	leaf        string
+	length      int // bytes
+	lines       int // '\n' bytes
+	runes       int // UTF-8 start bytes
+	height      int
+}
+
+// NewRope returns a rope holding s. It does not copy s.
+func NewRope(s string) Rope {
+	return Rope{root: buildRope(s)}
+}
+
+// RopeFromBuilder returns a rope holding a copy of the contents of b, so
+// later writes to b do not affect it.
+func RopeFromBuilder(b *Builder) Rope {
+	return NewRope(strings.Clone(b.String()))
+}
+
+// buildRope builds a perfectly balanced tree of ropeMaxLeaf leaves.
+func buildRope(s string) *ropeNode {
+	if len(s) == 0 {
+		return nil
+	}
+	if len(s) <= ropeMaxLeaf {
+		return newRopeLeaf(s)
+	}
+	leaves := (len(s) + ropeMaxLeaf - 1) / ropeMaxLeaf
+	mid := leaves / 2 * ropeMaxLeaf
+	return newRopeNode(buildRope(s[:mid]), buildRope(s[mid:]))
+}
+
+func newRopeLeaf(s string) *ropeNode {
+	n := &ropeNode{leaf: s, length: len(s), height: 1}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\n' {
+			n.lines++
+		}
+		if c&0xC0 != 0x80 {
+			n.runes++
+		}
+	}
+	return n
+}
+
+func newRopeNode(l, r *ropeNode) *ropeNode {
+	return &ropeNode{
+		left:   l,
+		right:  r,
+		length: l.length + r.length,
+		lines:  l.lines + r.lines,
+		runes:  l.runes + r.runes,
+		height: max(l.height, r.height) + 1,
+	}
+}
+
+func (n *ropeNode) isLeaf() bool { return n.left == nil }
+
+func ropeHeight(n *ropeNode) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// ropeJoin concatenates two balanced trees into a balanced tree.
+func ropeJoin(l, r *ropeNode) *ropeNode {
+	switch {
+	case l == nil:
+		return r
+	case r == nil:
+		return l
+	case l.isLeaf() && r.isLeaf() && l.length+r.length <= ropeMaxLeaf:
+		return newRopeLeaf(l.leaf + r.leaf)
+	case l.height > r.height+1:
+		return ropeBalance(l.left, ropeJoin(l.right, r))
+	case r.height > l.height+1:
+		return ropeBalance(ropeJoin(l, r.left), r.right)
+	}
+	return newRopeNode(l, r)
+}
+
+// ropeBalance returns a node for l and r, whose heights differ by at most
+// two, rotating as in an AVL tree if they differ by two.
+func ropeBalance(l, r *ropeNode) *ropeNode {
+	switch hl, hr := ropeHeight(l), ropeHeight(r); {
+	case hl > hr+1:
+		if ropeHeight(l.left) >= ropeHeight(l.right) {
+			return newRopeNode(l.left, newRopeNode(l.right, r))
+		}
+		return newRopeNode(newRopeNode(l.left, l.right.left), newRopeNode(l.right.right, r))
+	case hr > hl+1:
+		if ropeHeight(r.right) >= ropeHeight(r.left) {
+			return newRopeNode(newRopeNode(l, r.left), r.right)
+		}
+		return newRopeNode(newRopeNode(l, r.left.left), newRopeNode(r.left.right, r.right))
+	}
+	return newRopeNode(l, r)
+}
+
+// ropeSplit splits n at byte index i.
+func ropeSplit(n *ropeNode, i int) (*ropeNode, *ropeNode) {
+	switch {
+	case n == nil:
+		return nil, nil
+	case i <= 0:
+		return nil, n
+	case i >= n.length:
+		return n, nil
+	case n.isLeaf():
+		return newRopeLeaf(n.leaf[:i]), newRopeLeaf(n.leaf[i:])
+	case i < n.left.length:
+		ll, lr := ropeSplit(n.left, i)
+		return ll, ropeJoin(lr, n.right)
+	case i > n.left.length:
+		rl, rr := ropeSplit(n.right, i-n.left.length)
+		return ropeJoin(n.left, rl), rr
+	}
+	return n.left, n.right
+}
+
+// Len returns the length of r in bytes.
+func (r Rope) Len() int {
+	if r.root == nil {
+		return 0
+	}
+	return r.root.length
+}
+
+func (r Rope) checkRange(i, j int) {
+	if i < 0 || j < i || j > r.Len() {
+		panic("strings2.Rope: index out of range")
+	}
+}
+
+// Concat returns the concatenation of r and other.
+func (r Rope) Concat(other Rope) Rope {
+	return Rope{root: ropeJoin(r.root, other.root)}
+}
+
+// Insert returns r with s inserted before byte index i.
+// It panics if i is out of range.
+func (r Rope) Insert(i int, s string) Rope {
+	r.checkRange(i, i)
+	if s == "" {
+		return r
+	}
+	left, right := ropeSplit(r.root, i)
+	return Rope{root: ropeJoin(ropeJoin(left, buildRope(s)), right)}
+}
+
+// Delete returns r without the bytes in [i, j).
+// It panics if the range is invalid.
+func (r Rope) Delete(i, j int) Rope {
+	r.checkRange(i, j)
+	left, rest := ropeSplit(r.root, i)
+	_, right := ropeSplit(rest, j-i)
+	return Rope{root: ropeJoin(left, right)}
+}
+
+// Slice returns the bytes in [i, j) of r as a rope sharing r's leaves.
+// It panics if the range is invalid.
+func (r Rope) Slice(i, j int) Rope {
+	r.checkRange(i, j)
+	_, rest := ropeSplit(r.root, i)
+	mid, _ := ropeSplit(rest, j-i)
+	return Rope{root: mid}
+}
+
+// ByteAt returns the byte at index i. It panics if i is out of range.
+func (r Rope) ByteAt(i int) byte {
+	if i < 0 || i >= r.Len() {
+		panic("strings2.Rope: index out of range")
+	}
+	n := r.root
+	for !n.isLeaf() {
+		if i < n.left.length {
+			n = n.left
+		} else {
+			i -= n.left.length
+			n = n.right
+		}
+	}
+	return n.leaf[i]
+}
+
+// String returns the contents of r as one string. A rope of a single leaf
+// is returned without copying.
+func (r Rope) String() string {
+	switch {
+	case r.root == nil:
+		return ""
+	case r.root.isLeaf():
+		return r.root.leaf
+	}
+	buf := MakeNoZeroCap(0, r.root.length)
+	for c := range r.Chunks() {
+		buf = append(buf, c...)
+	}
+	return unsafeString(buf)
+}
+
+// Builder returns a new Builder holding the contents of r.
+func (r Rope) Builder() *Builder {
+	b := NewBuilder(r.Len())
+	r.WriteTo(b)
+	return b
+}
+
+// WriteTo writes the contents of r to w leaf by leaf.
+func (r Rope) WriteTo(w io.Writer) (n int64, err error) {
+	for c := range r.Chunks() {
+		m, err := io.WriteString(w, c)
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// Chunks returns an iterator over the leaves of r, in order.
+func (r Rope) Chunks() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		ropeWalk(r.root, yield)
+	}
+}
+
+func ropeWalk(n *ropeNode, yield func(string) bool) bool {
+	if n == nil {
+		return true
+	}
+	if n.isLeaf() {
+		return yield(n.leaf)
+	}
+	return ropeWalk(n.left, yield) && ropeWalk(n.right, yield)
+}
+
+// Runes returns an iterator over the runes of r and their byte offsets, as
+// a range loop over r.String() would produce, including for runes whose
+// encoding spans several leaves.
+func (r Rope) Runes() iter.Seq2[int, rune] {
+	return func(yield func(int, rune) bool) {
+		// carry holds the start of an encoding cut off at the end of a leaf.
+		var carry [utf8.UTFMax]byte
+		nc, pos := 0, 0
+		for c := range r.Chunks() {
+			i := 0
+			for nc > 0 {
+				for !utf8.FullRune(carry[:nc]) && i < len(c) {
+					carry[nc] = c[i]
+					nc++
+					i++
+				}
+				if !utf8.FullRune(carry[:nc]) {
+					break
+				}
+				ru, wid := utf8.DecodeRune(carry[:nc])
+				if !yield(pos+i-nc, ru) {
+					return
+				}
+				nc = copy(carry[:], carry[wid:nc])
+			}
+			for i < len(c) {
+				if c[i] < utf8.RuneSelf {
+					if !yield(pos+i, rune(c[i])) {
+						return
+					}
+					i++
+					continue
+				}
+				if !utf8.FullRuneInString(c[i:]) {
+					nc = copy(carry[:], c[i:])
+					break
+				}
+				ru, wid := utf8.DecodeRuneInString(c[i:])
+				if !yield(pos+i, ru) {
+					return
+				}
+				i += wid
+			}
+			pos += len(c)
+		}
+		for nc > 0 {
+			// Truncated encoding at the end of the rope.
+			ru, wid := utf8.DecodeRune(carry[:nc])
+			if !yield(pos-nc, ru) {
+				return
+			}
+			nc = copy(carry[:], carry[wid:nc])
+		}
+	}
+}
+
+// Index returns the byte index of the first instance of substr in r, or
+// -1 if substr is not present. Matches that span leaves are found by
+// keeping the last len(substr)-1 bytes of the previous leaves.
+func (r Rope) Index(substr string) int {
+	n := len(substr)
+	switch {
+	case n == 0:
+		return 0
+	case n > r.Len():
+		return -1
+	case r.root.isLeaf():
+		return strings.Index(r.root.leaf, substr)
+	}
+	var carry, window []byte
+	pos := 0
+	for c := range r.Chunks() {
+		if len(carry) > 0 {
+			window = append(append(window[:0], carry...), c[:min(n-1, len(c))]...)
+			if i := strings.Index(unsafeString(window), substr); i >= 0 {
+				return pos - len(carry) + i
+			}
+		}
+		if i := strings.Index(c, substr); i >= 0 {
+			return pos + i
+		}
+		if len(c) >= n-1 {
+			carry = append(carry[:0], c[len(c)-(n-1):]...)
+		} else {
+			carry = append(carry, c...)
+			if len(carry) > n-1 {
+				carry = carry[len(carry)-(n-1):]
+			}
+		}
+		pos += len(c)
+	}
+	return -1
+}
+
+// LineCount returns the number of lines in r: one more than the number of
+// '\n' bytes, so a trailing newline starts an empty last line.
+func (r Rope) LineCount() int {
+	if r.root == nil {
+		return 1
+	}
+	return r.root.lines + 1
+}
+
+// LineStart returns the byte offset at which line n (counting from 0)
+// begins. It panics if n is not in [0, r.LineCount()).
+func (r Rope) LineStart(n int) int {
+	if n < 0 || n >= r.LineCount() {
+		panic("strings2.Rope: line out of range")
+	}
+	if n == 0 {
+		return 0
+	}
+	// Find the n-th newline; the line starts after it.
+	node, off := r.root, 0
+	for !node.isLeaf() {
+		if n <= node.left.lines {
+			node = node.left
+		} else {
+			n -= node.left.lines
+			off += node.left.length
+			node = node.right
+		}
+	}
+	i := 0
+	for ; n > 0; n-- {
+		i += strings.IndexByte(node.leaf[i:], '\n') + 1
+	}
+	return off + i
+}
+
+// LineAt returns the line (counting from 0) containing byte offset i.
+// It panics if i is not in [0, r.Len()].
+func (r Rope) LineAt(i int) int {
+	r.checkRange(i, i)
+	line, node := 0, r.root
+	for node != nil && !node.isLeaf() {
+		if i < node.left.length {
+			node = node.left
+		} else {
+			line += node.left.lines
+			i -= node.left.length
+			node = node.right
+		}
+	}
+	if node != nil {
+		line += strings.Count(node.leaf[:i], "\n")
+	}
+	return line
+}
+
+// RuneCount returns the number of runes in r.
+func (r Rope) RuneCount() int {
+	if r.root == nil {
+		return 0
+	}
+	return r.root.runes
+}
+
+// RuneOffset returns the byte offset of the rune with index k, or r.Len()
+// if k == r.RuneCount(). It panics if k is out of range.
+func (r Rope) RuneOffset(k int) int {
+	if k < 0 || k > r.RuneCount() {
+		panic("strings2.Rope: rune index out of range")
+	}
+	if k == r.RuneCount() {
+		return r.Len()
+	}
+	node, off := r.root, 0
+	for !node.isLeaf() {
+		if k < node.left.runes {
+			node = node.left
+		} else {
+			k -= node.left.runes
+			off += node.left.length
+			node = node.right
+		}
+	}
+	for i := 0; ; i++ {
+		if node.leaf[i]&0xC0 != 0x80 {
+			if k == 0 {
+				return off + i
+			}
+			k--
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package strings2
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// checkRope verifies the cached counts and the balance of every node.
+func checkRope(t *testing.T, n *ropeNode) {
+	t.Helper()
+	if n == nil {
+		return
+	}
+	if n.isLeaf() {
+		if n.length != len(n.leaf) || n.height != 1 {
+			t.Fatalf("bad leaf %+v", n)
+		}
+		return
+	}
+	checkRope(t, n.left)
+	checkRope(t, n.right)
+	if d := n.left.height - n.right.height; d < -1 || d > 1 {
+		t.Fatalf("unbalanced node: heights %d and %d", n.left.height, n.right.height)
+	}
+	if n.length != n.left.length+n.right.length || n.lines != n.left.lines+n.right.lines ||
+		n.runes != n.left.runes+n.right.runes || n.height != max(n.left.height, n.right.height)+1 {
+		t.Fatal("bad cached counts")
+	}
+}
+
+func checkRopeContent(t *testing.T, r Rope, want string) {
+	t.Helper()
+	checkRope(t, r.root)
+	if r.Len() != len(want) || r.String() != want {
+		t.Fatalf("rope content mismatch: len %d, want %d", r.Len(), len(want))
+	}
+	if r.LineCount() != strings.Count(want, "\n")+1 {
+		t.Fatalf("LineCount = %d", r.LineCount())
+	}
+	if r.RuneCount() != utf8.RuneCountInString(want) {
+		t.Fatalf("RuneCount = %d, want %d", r.RuneCount(), utf8.RuneCountInString(want))
+	}
+}
+
+func TestRopeRandomEdits(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	pieces := []string{"a", "hello ", "line\n", "ж", "世界", "\n", strings.Repeat("xyz", 500), strings.Repeat("ё", 700)}
+	var r Rope
+	model := ""
+	// Edit at rune boundaries, as an editor would, so rune counts stay
+	// comparable with the utf8 package.
+	runeStart := func(i int) int {
+		for i < len(model) && !utf8.RuneStart(model[i]) {
+			i++
+		}
+		return i
+	}
+	for step := range 2000 {
+		switch op := rng.Intn(10); {
+		case op < 6 || len(model) == 0:
+			i := runeStart(rng.Intn(len(model) + 1))
+			s := pieces[rng.Intn(len(pieces))]
+			r = r.Insert(i, s)
+			model = model[:i] + s + model[i:]
+		case op < 9:
+			i := runeStart(rng.Intn(len(model)))
+			j := runeStart(i + rng.Intn(min(len(model)-i, 3000)+1))
+			r = r.Delete(i, j)
+			model = model[:i] + model[j:]
+		default:
+			i := rng.Intn(len(model))
+			j := i + rng.Intn(len(model)-i+1)
+			if got := r.Slice(i, j).String(); got != model[i:j] {
+				t.Fatalf("step %d: Slice(%d, %d) mismatch", step, i, j)
+			}
+		}
+		if step%50 == 0 {
+			checkRopeContent(t, r, model)
+		}
+	}
+	checkRopeContent(t, r, model)
+
+	for i := 0; i < len(model); i += 97 {
+		if r.ByteAt(i) != model[i] {
+			t.Fatalf("ByteAt(%d) mismatch", i)
+		}
+	}
+	var runes []int
+	for i, c := range r.Runes() {
+		runes = append(runes, i, int(c))
+	}
+	var want []int
+	for i, c := range model {
+		want = append(want, i, int(c))
+	}
+	if len(runes) != len(want) {
+		t.Fatalf("Runes yielded %d values, want %d", len(runes)/2, len(want)/2)
+	}
+	for k := range want {
+		if runes[k] != want[k] {
+			t.Fatalf("Runes mismatch at %d", k/2)
+		}
+	}
+}
+
+func TestRopeRunesSplitLeaves(t *testing.T) {
+	// Leaves of one byte each, cut inside multi-byte runes, and invalid
+	// UTF-8, including a truncated encoding at the end.
+	s := "aж€😀\xff\xe2\x82z\xf0\x9f"
+	r := Rope{root: newRopeLeaf(s[:1])}
+	for i := 1; i < len(s); i++ {
+		r.root = newRopeNode(r.root, newRopeLeaf(s[i:i+1]))
+	}
+	var got, want []rune
+	var offs, wantOffs []int
+	for i, c := range r.Runes() {
+		got, offs = append(got, c), append(offs, i)
+	}
+	for i, c := range s {
+		want, wantOffs = append(want, c), append(wantOffs, i)
+	}
+	if string(got) != string(want) || len(offs) != len(wantOffs) {
+		t.Fatalf("Runes = %q %v, want %q %v", got, offs, want, wantOffs)
+	}
+	for k := range offs {
+		if offs[k] != wantOffs[k] {
+			t.Fatalf("Runes offsets = %v, want %v", offs, wantOffs)
+		}
+	}
+}
+
+func TestRopeIndex(t *testing.T) {
+	s := strings.Repeat("abcdefghij", 500) + "needle" + strings.Repeat("k", 3000) + "end"
+	r := NewRope(s)
+	// Cut the needle across leaves.
+	r = r.Slice(0, 5003).Concat(r.Slice(5003, len(s)))
+	for _, sub := range []string{"", "needle", "end", "abc", "jab", "kkkke", "nope", "dle" + "kk", s} {
+		if got, want := r.Index(sub), strings.Index(s, sub); got != want {
+			t.Errorf("Index(%.20q) = %d, want %d", sub, got, want)
+		}
+	}
+	if NewRope("short").Index("longer than rope") != -1 {
+		t.Fatal("Index of long substr")
+	}
+}
+
+func TestRopeLinesAndRunes(t *testing.T) {
+	s := "first line\nвторая\n\n" + strings.Repeat("long line ", 300) + "\nlast"
+	r := NewRope(s).Insert(0, "").Delete(0, 0)
+	starts := []int{0}
+	for i := range len(s) {
+		if s[i] == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	if r.LineCount() != len(starts) {
+		t.Fatalf("LineCount = %d, want %d", r.LineCount(), len(starts))
+	}
+	for n, want := range starts {
+		if got := r.LineStart(n); got != want {
+			t.Errorf("LineStart(%d) = %d, want %d", n, got, want)
+		}
+	}
+	for i := 0; i <= len(s); i += 7 {
+		if got, want := r.LineAt(i), strings.Count(s[:i], "\n"); got != want {
+			t.Errorf("LineAt(%d) = %d, want %d", i, got, want)
+		}
+	}
+	k := 0
+	for i := range s {
+		if got := r.RuneOffset(k); got != i {
+			t.Fatalf("RuneOffset(%d) = %d, want %d", k, got, i)
+		}
+		k++
+	}
+	if r.RuneOffset(k) != len(s) {
+		t.Fatal("RuneOffset(RuneCount) != Len")
+	}
+
+	var empty Rope
+	if empty.LineCount() != 1 || empty.LineStart(0) != 0 || empty.LineAt(0) != 0 || empty.String() != "" {
+		t.Fatal("empty rope")
+	}
+}
+
+func TestRopeBuilder(t *testing.T) {
+	b := NewBuilder(0)
+	b.WriteString("from builder")
+	r := RopeFromBuilder(b)
+	b.ResetAndKeepCap()
+	b.WriteString("overwritten!")
+	if r.String() != "from builder" {
+		t.Fatalf("rope changed with builder: %q", r.String())
+	}
+	r = r.Insert(4, " the")
+	if got := r.Builder().String(); got != "from the builder" {
+		t.Fatalf("Builder() = %q", got)
+	}
+
+	var chunks []string
+	for c := range NewRope(strings.Repeat("q", 3000)).Chunks() {
+		chunks = append(chunks, c)
+	}
+	if len(chunks) != 3 || strings.Join(chunks, "") != strings.Repeat("q", 3000) {
+		t.Fatalf("Chunks returned %d leaves", len(chunks))
+	}
+
+	for _, f := range []func(){
+		func() { r.Insert(-1, "x") },
+		func() { r.Delete(2, 1) },
+		func() { r.Slice(0, r.Len()+1) },
+		func() { r.ByteAt(r.Len()) },
+		func() { r.LineStart(r.LineCount()) },
+		func() { r.RuneOffset(-1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func BenchmarkRopeInsert(b *testing.B) {
+	r := NewRope(strings.Repeat("some document text\n", 1<<16))
+	b.ReportAllocs()
+	i := 0
+	for b.Loop() {
+		i = (i + 7919) % r.Len()
+		r = r.Insert(i, "x").Delete(i, i+1)
+	}
+}
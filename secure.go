@@ -0,0 +1,137 @@
+package strings2
+
+import (
+	"fmt"
+	"io"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// SecureBuilder builds byte strings that hold secrets such as passwords or
+// tokens. Unlike Builder, it never leaves a copy of its contents in memory
+// it has let go of: when the buffer grows, the old one is cleared before it
+// is dropped, and Reset and Destroy clear the buffer in place.
+//
+// To keep the contents from being copied into ordinary strings by
+// accident, SecureBuilder has no String method; it formats as [REDACTED]
+// with the fmt package, and its contents are only reachable through
+// WriteTo or the explicitly unsafe UnsafeString.
+//
+// Secrets passed to the write methods, and anything the caller derives
+// from UnsafeString, are outside the builder's control.
+type SecureBuilder struct {
+	noCopy noCopy
+	buf    []byte
+}
+
+var (
+	_ io.Writer       = (*SecureBuilder)(nil)
+	_ io.ByteWriter   = (*SecureBuilder)(nil)
+	_ io.StringWriter = (*SecureBuilder)(nil)
+	_ io.WriterTo     = (*SecureBuilder)(nil)
+	_ fmt.Formatter   = (*SecureBuilder)(nil)
+)
+
+// NewSecureBuilder returns a SecureBuilder with preallocated cap. Sizing
+// cap for the whole secret avoids growing, and so copying, at all.
+func NewSecureBuilder(cap int) *SecureBuilder {
+	return &SecureBuilder{buf: MakeNoZeroCap(0, cap)}
+}
+
+// Len returns the number of accumulated bytes.
+func (b *SecureBuilder) Len() int { return len(b.buf) }
+
+// Cap returns the capacity of the builder's buffer.
+func (b *SecureBuilder) Cap() int { return cap(b.buf) }
+
+// Grow grows b's capacity, if necessary, to guarantee space for another n
+// bytes. The old buffer is cleared after its contents are copied.
+func (b *SecureBuilder) Grow(n int) {
+	if n < 0 {
+		panic("strings2.SecureBuilder.Grow: negative count")
+	}
+	if cap(b.buf)-len(b.buf) >= n {
+		return
+	}
+	buf := MakeNoZero(2*cap(b.buf) + n)[:len(b.buf)]
+	copy(buf, b.buf)
+	memclr(b.buf[:cap(b.buf)])
+	b.buf = buf
+}
+
+// Write appends the contents of p to b's buffer.
+// Write always returns len(p), nil.
+func (b *SecureBuilder) Write(p []byte) (int, error) {
+	b.Grow(len(p))
+	b.buf = append(b.buf, p...)
+	return len(p), nil
+}
+
+// WriteByte appends the byte c to b's buffer.
+// The returned error is always nil.
+func (b *SecureBuilder) WriteByte(c byte) error {
+	b.Grow(1)
+	b.buf = append(b.buf, c)
+	return nil
+}
+
+// WriteRune appends the UTF-8 encoding of Unicode code point r to b's
+// buffer. It returns the length of r and a nil error.
+func (b *SecureBuilder) WriteRune(r rune) (int, error) {
+	b.Grow(utf8.UTFMax)
+	n := len(b.buf)
+	b.buf = utf8.AppendRune(b.buf, r)
+	return len(b.buf) - n, nil
+}
+
+// WriteString appends the contents of s to b's buffer.
+// It returns the length of s and a nil error.
+func (b *SecureBuilder) WriteString(s string) (int, error) {
+	b.Grow(len(s))
+	b.buf = append(b.buf, s...)
+	return len(s), nil
+}
+
+// WriteTo writes the accumulated bytes to w without creating a string.
+// The builder keeps its contents.
+func (b *SecureBuilder) WriteTo(w io.Writer) (n int64, err error) {
+	if len(b.buf) == 0 {
+		return 0, nil
+	}
+	m, err := w.Write(b.buf)
+	if m > len(b.buf) {
+		panic("strings2.SecureBuilder.WriteTo: invalid Write count")
+	}
+	if err == nil && m != len(b.buf) {
+		err = io.ErrShortWrite
+	}
+	return int64(m), err
+}
+
+// UnsafeString returns the accumulated bytes as a string without copying.
+// The string aliases the builder's buffer: it is cleared to NULs by Reset,
+// Destroy and the next grow, so it must not be retained, and copying it
+// defeats the purpose of the builder.
+func (b *SecureBuilder) UnsafeString() string {
+	return unsafe.String(unsafe.SliceData(b.buf), len(b.buf))
+}
+
+// Format implements fmt.Formatter so that printing the builder, with any
+// verb, never reveals its contents.
+func (b *SecureBuilder) Format(f fmt.State, verb rune) {
+	io.WriteString(f, "[REDACTED]")
+}
+
+// Reset clears the whole buffer and empties the builder, keeping its
+// capacity for reuse.
+func (b *SecureBuilder) Reset() {
+	memclr(b.buf[:cap(b.buf)])
+	b.buf = b.buf[:0]
+}
+
+// Destroy clears the whole buffer and releases it. The builder can be
+// reused afterwards; the next write allocates a new buffer.
+func (b *SecureBuilder) Destroy() {
+	memclr(b.buf[:cap(b.buf)])
+	b.buf = nil
+}
@@ -0,0 +1,72 @@
+package strings2
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func isZeroed(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSecureBuilder(t *testing.T) {
+	b := NewSecureBuilder(8)
+	b.WriteString("user:")
+	b.Write([]byte("pa"))
+	b.WriteByte('s')
+	b.WriteRune('✓')
+	if got, want := b.UnsafeString(), "user:pas✓"; got != want {
+		t.Fatalf("UnsafeString = %q, want %q", got, want)
+	}
+	if b.Len() != len("user:pas✓") {
+		t.Fatalf("Len = %d", b.Len())
+	}
+
+	var out strings.Builder
+	if n, err := b.WriteTo(&out); err != nil || n != int64(b.Len()) || out.String() != b.UnsafeString() {
+		t.Fatalf("WriteTo: n=%d err=%v out=%q", n, err, out.String())
+	}
+
+	for _, verb := range []string{"%v", "%s", "%+v", "%#v", "%q", "%x"} {
+		if got := fmt.Sprintf(verb, b); got != "[REDACTED]" {
+			t.Errorf("Sprintf(%s) = %q", verb, got)
+		}
+	}
+}
+
+func TestSecureBuilderWipes(t *testing.T) {
+	b := NewSecureBuilder(8)
+	b.WriteString("password")
+	old := b.buf[:cap(b.buf)]
+	b.WriteString("123")
+	if !isZeroed(old) {
+		t.Fatalf("old buffer not cleared on grow: %q", old)
+	}
+	if got := b.UnsafeString(); got != "password123" {
+		t.Fatalf("content after grow = %q", got)
+	}
+
+	buf := b.buf[:cap(b.buf)]
+	c := b.Cap()
+	b.Reset()
+	if !isZeroed(buf) || b.Len() != 0 || b.Cap() != c {
+		t.Fatalf("Reset: buf=%q len=%d cap=%d", buf, b.Len(), b.Cap())
+	}
+
+	b.WriteString("token")
+	buf = b.buf[:cap(b.buf)]
+	b.Destroy()
+	if !isZeroed(buf) || b.Len() != 0 || b.Cap() != 0 {
+		t.Fatalf("Destroy: buf=%q len=%d cap=%d", buf, b.Len(), b.Cap())
+	}
+	b.WriteString("again")
+	if b.UnsafeString() != "again" {
+		t.Fatal("builder not reusable after Destroy")
+	}
+}
@@ -0,0 +1,379 @@
+package strings2
+
+import (
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Locale selects the language-specific rules of SpecialCasing.txt used by
+// ToUpperSpecial and ToLowerSpecial.
+type Locale uint8
+
+const (
+	LocaleRoot       Locale = iota // language-neutral rules
+	LocaleTurkish                  // tr: dotted and dotless i
+	LocaleAzeri                    // az: same rules as tr
+	LocaleLithuanian               // lt: keeps the dot on i under accents
+	LocaleGreek                    // el: uppercase drops accents
+)
+
+var localeNames = [...]string{
+	LocaleRoot:       "",
+	LocaleTurkish:    "tr",
+	LocaleAzeri:      "az",
+	LocaleLithuanian: "lt",
+	LocaleGreek:      "el",
+}
+
+// String returns the language subtag of l, or "" for LocaleRoot.
+func (l Locale) String() string {
+	if int(l) < len(localeNames) {
+		return localeNames[l]
+	}
+	return ""
+}
+
+// ParseLocale returns the Locale for a BCP 47 language tag such as "tr",
+// "tr-TR" or "el_GR". Only the language subtag is looked at; tags without
+// special casing rules map to LocaleRoot.
+func ParseLocale(tag string) Locale {
+	lang := tag
+	for i := 0; i < len(tag); i++ {
+		if tag[i] == '-' || tag[i] == '_' {
+			lang = tag[:i]
+			break
+		}
+	}
+	for l, name := range localeNames {
+		if name != "" && EqualFold(lang, name) {
+			return Locale(l)
+		}
+	}
+	return LocaleRoot
+}
+
+func (l Locale) turkic() bool { return l == LocaleTurkish || l == LocaleAzeri }
+
+// ToUpperSpecial returns s with all Unicode letters mapped to their upper
+// case using the full mappings of SpecialCasing.txt (ß becomes "SS") and
+// the rules of loc. ASCII input outside tr and az takes the ToUpper fast
+// path.
+func ToUpperSpecial(loc Locale, s string) string {
+	isASCII, hasI := true, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			isASCII = false
+			break
+		}
+		hasI = hasI || c == 'i'
+	}
+	if isASCII && !(hasI && loc.turkic()) {
+		return ToUpper(s)
+	}
+
+	b := NewBuilder(len(s) + len(s)>>2)
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf && !(c == 'i' && loc.turkic()) {
+			b.WriteByte(upperTable[c])
+			i++
+			continue
+		}
+		r, wid := utf8.DecodeRuneInString(s[i:])
+		upperSpecialRune(b, loc, s, i, r)
+		i += wid
+	}
+	return b.String()
+}
+
+func upperSpecialRune(b *Builder, loc Locale, s string, i int, r rune) {
+	switch loc {
+	case LocaleTurkish, LocaleAzeri:
+		if r == 'i' {
+			b.WriteRune('\u0130')
+			return
+		}
+	case LocaleLithuanian:
+		// Remove the dot above a soft-dotted letter: i U+0307 -> I.
+		if r == '\u0307' && afterSoftDotted(s, i) {
+			return
+		}
+	case LocaleGreek:
+		if greekUpperRune(b, s, i, r) {
+			return
+		}
+	}
+	if to, ok := lookupSpecial(specialUpper[:], r); ok {
+		b.WriteString(to)
+		return
+	}
+	b.WriteRune(unicode.ToUpper(r))
+}
+
+// ToLowerSpecial returns s with all Unicode letters mapped to their lower
+// case using the full mappings of SpecialCasing.txt, including the final
+// form of sigma, and the rules of loc. ASCII input outside tr, az and lt
+// takes the ToLower fast path.
+func ToLowerSpecial(loc Locale, s string) string {
+	isASCII, hasI := true, false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			isASCII = false
+			break
+		}
+		hasI = hasI || c == 'I'
+	}
+	if isASCII && !(hasI && loc.turkic()) {
+		// lt only differs before combining marks, which are not ASCII.
+		return ToLower(s)
+	}
+
+	b := NewBuilder(len(s) + len(s)>>2)
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf && !(c == 'I' && loc != LocaleRoot) && !(c == 'J' && loc == LocaleLithuanian) {
+			b.WriteByte(lowerTable[c])
+			i++
+			continue
+		}
+		r, wid := utf8.DecodeRuneInString(s[i:])
+		lowerSpecialRune(b, loc, s, i, i+wid, r)
+		i += wid
+	}
+	return b.String()
+}
+
+func lowerSpecialRune(b *Builder, loc Locale, s string, start, end int, r rune) {
+	switch loc {
+	case LocaleTurkish, LocaleAzeri:
+		switch {
+		case r == '\u0130':
+			b.WriteByte('i')
+			return
+		case r == 'I':
+			// I followed by a combining dot above is a dotted i.
+			if beforeDot(s, end) {
+				b.WriteByte('i')
+			} else {
+				b.WriteRune('\u0131')
+			}
+			return
+		case r == '\u0307' && afterI(s, start):
+			return
+		}
+	case LocaleLithuanian:
+		// Keep the dot on i and j when more accents follow.
+		switch r {
+		case 'I', 'J', '\u012E':
+			b.WriteRune(unicode.ToLower(r))
+			if moreAbove(s, end) {
+				b.WriteRune('\u0307')
+			}
+			return
+		case '\u00CC':
+			b.WriteString("i\u0307\u0300")
+			return
+		case '\u00CD':
+			b.WriteString("i\u0307\u0301")
+			return
+		case '\u0128':
+			b.WriteString("i\u0307\u0303")
+			return
+		}
+	}
+
+	switch r {
+	case 'Σ':
+		if finalSigma(s, start, end) {
+			b.WriteRune('ς')
+		} else {
+			b.WriteRune('σ')
+		}
+	case '\u0130':
+		b.WriteString("i\u0307")
+	default:
+		b.WriteRune(unicode.ToLower(r))
+	}
+}
+
+// greekUpperRune applies the el uppercasing rules: accents and breathings
+// are dropped, the dialytika is kept.
+func greekUpperRune(b *Builder, s string, i int, r rune) bool {
+	k, ok := slices.BinarySearchFunc(greekUpper[:], r, func(g greekCase, r rune) int {
+		return int(g.r - r)
+	})
+	if ok {
+		g := greekUpper[k]
+		switch {
+		case g.flags&greekDialytika == 0:
+			b.WriteRune(g.base)
+		case g.base == '\u0399':
+			b.WriteRune('\u03AA')
+		case g.base == '\u03A5':
+			b.WriteRune('\u03AB')
+		default:
+			b.WriteRune(g.base)
+			b.WriteRune('\u0308')
+		}
+		if g.flags&greekIota != 0 {
+			b.WriteRune('\u0399')
+		}
+		return true
+	}
+
+	switch r {
+	case '\u0300', '\u0301', '\u0342', '\u0313', '\u0314', '\u0343':
+		return afterGreek(s, i)
+	case '\u0344':
+		if afterGreek(s, i) {
+			b.WriteRune('\u0308')
+			return true
+		}
+	}
+	return false
+}
+
+func lookupSpecial(table []specialCase, r rune) (string, bool) {
+	i, ok := slices.BinarySearchFunc(table, r, func(c specialCase, r rune) int {
+		return int(c.r - r)
+	})
+	if !ok {
+		return "", false
+	}
+	return table[i].to, true
+}
+
+// Casing contexts from section 3.13 of the Unicode Standard.
+
+func isCased(r rune) bool {
+	return unicode.IsUpper(r) || unicode.IsLower(r) || unicode.IsTitle(r) ||
+		unicode.Is(unicode.Other_Lowercase, r) || unicode.Is(unicode.Other_Uppercase, r)
+}
+
+func isCaseIgnorable(r rune) bool {
+	switch r {
+	case '\'', '.', ':', '\u00B7', '\u0387', '\u05F4', '\u2018', '\u2019',
+		'\u2024', '\u2027', '\uFE13', '\uFE52', '\uFE55', '\uFF07', '\uFF0E', '\uFF1A':
+		return true
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, unicode.Lm, unicode.Sk)
+}
+
+// finalSigma reports whether the sigma at s[start:end] is preceded by a
+// cased letter and not followed by one, ignoring case-ignorable runes.
+func finalSigma(s string, start, end int) bool {
+	i := start
+	for {
+		if i == 0 {
+			return false
+		}
+		r, wid := utf8.DecodeLastRuneInString(s[:i])
+		i -= wid
+		if isCaseIgnorable(r) {
+			continue
+		}
+		if !isCased(r) {
+			return false
+		}
+		break
+	}
+	for j := end; j < len(s); {
+		r, wid := utf8.DecodeRuneInString(s[j:])
+		j += wid
+		if isCaseIgnorable(r) {
+			continue
+		}
+		return !isCased(r)
+	}
+	return true
+}
+
+// isBlocker reports whether r has combining class 0 or 230, which ends
+// the search in the More_Above, Before_Dot, After_I and After_Soft_Dotted
+// contexts.
+func isBlocker(r rune) bool {
+	return !unicode.Is(cccNonZero, r) || unicode.Is(cccAbove, r)
+}
+
+// moreAbove reports whether s[i:] starts with a combining mark of class
+// 230 with no starter in between.
+func moreAbove(s string, i int) bool {
+	for i < len(s) {
+		r, wid := utf8.DecodeRuneInString(s[i:])
+		if unicode.Is(cccAbove, r) {
+			return true
+		}
+		if isBlocker(r) {
+			return false
+		}
+		i += wid
+	}
+	return false
+}
+
+// beforeDot reports whether s[i:] starts with U+0307 COMBINING DOT ABOVE,
+// possibly after other combining marks.
+func beforeDot(s string, i int) bool {
+	for i < len(s) {
+		r, wid := utf8.DecodeRuneInString(s[i:])
+		if r == '\u0307' {
+			return true
+		}
+		if isBlocker(r) {
+			return false
+		}
+		i += wid
+	}
+	return false
+}
+
+// afterI reports whether s[:i] ends with an uppercase I, possibly followed
+// by other combining marks.
+func afterI(s string, i int) bool {
+	for i > 0 {
+		r, wid := utf8.DecodeLastRuneInString(s[:i])
+		if r == 'I' {
+			return true
+		}
+		if isBlocker(r) {
+			return false
+		}
+		i -= wid
+	}
+	return false
+}
+
+// afterSoftDotted reports whether s[:i] ends with a soft-dotted letter
+// such as i or j, possibly followed by other combining marks.
+func afterSoftDotted(s string, i int) bool {
+	for i > 0 {
+		r, wid := utf8.DecodeLastRuneInString(s[:i])
+		if unicode.Is(unicode.Soft_Dotted, r) {
+			return true
+		}
+		if isBlocker(r) {
+			return false
+		}
+		i -= wid
+	}
+	return false
+}
+
+// afterGreek reports whether s[:i] ends with a Greek letter, possibly
+// followed by combining marks.
+func afterGreek(s string, i int) bool {
+	for i > 0 {
+		r, wid := utf8.DecodeLastRuneInString(s[:i])
+		if unicode.Is(unicode.Greek, r) {
+			return true
+		}
+		if !unicode.Is(unicode.Mn, r) {
+			return false
+		}
+		i -= wid
+	}
+	return false
+}
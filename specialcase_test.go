@@ -0,0 +1,140 @@
+package strings2
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpecialCaseRoot(t *testing.T) {
+	tests := []struct {
+		s, upper, lower string
+	}{
+		{"hello", "HELLO", "hello"},
+		{"Привет Мир", "ПРИВЕТ МИР", "привет мир"},
+		{"straße", "STRASSE", "straße"},
+		{"ﬁnance ﬂow", "FINANCE FLOW", "ﬁnance ﬂow"},
+		{"ŉ", "ʼN", "ŉ"},
+		{"ǰ", "J̌", "ǰ"},
+		{"ΐ", "Ϊ́", "ΐ"},
+		{"ᾳ ᾀ", "ΑΙ ἈΙ", "ᾳ ᾀ"},
+		{"İstanbul", "İSTANBUL", "i̇stanbul"},
+		{"Σ", "Σ", "σ"},
+		{"ΣΣ", "ΣΣ", "σς"},
+		{"ΣΑΣ ΟΔΥΣΣΕΥΣ", "ΣΑΣ ΟΔΥΣΣΕΥΣ", "σας οδυσσευς"},
+		{"ὈΔΥΣΣΕΎΣ.", "ὈΔΥΣΣΕΎΣ.", "ὀδυσσεύς."},
+		{"A.Σ", "A.Σ", "a.ς"},
+		{"AΣ́b", "AΣ́B", "aσ́b"},
+		{"ά", "Ά", "ά"},
+	}
+	for _, tt := range tests {
+		if got := ToUpperSpecial(LocaleRoot, tt.s); got != tt.upper {
+			t.Errorf("ToUpperSpecial(%q) = %q, want %q", tt.s, got, tt.upper)
+		}
+		if got := ToLowerSpecial(LocaleRoot, tt.s); got != tt.lower {
+			t.Errorf("ToLowerSpecial(%q) = %q, want %q", tt.s, got, tt.lower)
+		}
+	}
+}
+
+func TestSpecialCaseLocale(t *testing.T) {
+	tests := []struct {
+		name  string
+		loc   Locale
+		upper bool
+		s     string
+		want  string
+	}{
+		{"tr upper i", LocaleTurkish, true, "istanbul", "İSTANBUL"},
+		{"tr upper dotless", LocaleTurkish, true, "ırmak", "IRMAK"},
+		{"tr lower I", LocaleTurkish, false, "ISPARTA", "ısparta"},
+		{"tr lower dotted I", LocaleTurkish, false, "İzmir", "izmir"},
+		{"tr lower I dot above", LocaleTurkish, false, "İ", "i"},
+		{"tr lower I mark dot above", LocaleTurkish, false, "Ị̇", "ị"},
+		{"az upper i", LocaleAzeri, true, "bir", "BİR"},
+		{"az lower I", LocaleAzeri, false, "BIR", "bır"},
+		{"lt lower I grave", LocaleLithuanian, false, "Ì", "i̇̀"},
+		{"lt lower I acute", LocaleLithuanian, false, "Í", "i̇́"},
+		{"lt lower I tilde", LocaleLithuanian, false, "Ĩ", "i̇̃"},
+		{"lt lower I combining", LocaleLithuanian, false, "Í", "i̇́"},
+		{"lt lower J combining", LocaleLithuanian, false, "J̃", "j̇̃"},
+		{"lt lower I plain", LocaleLithuanian, false, "IX", "ix"},
+		{"lt upper soft dotted", LocaleLithuanian, true, "i̇", "I"},
+		{"lt upper soft dotted accent", LocaleLithuanian, true, "i̇́", "Í"},
+		{"lt upper dot elsewhere", LocaleLithuanian, true, "ȧ", "Ȧ"},
+		{"el upper tonos", LocaleGreek, true, "Οδυσσεύς", "ΟΔΥΣΣΕΥΣ"},
+		{"el upper dialytika tonos", LocaleGreek, true, "ΐ", "Ϊ"},
+		{"el upper ypogegrammeni", LocaleGreek, true, "ᾳ", "ΑΙ"},
+		{"el upper combining acute", LocaleGreek, true, "ά", "Α"},
+		{"el upper breathing", LocaleGreek, true, "ἀ", "Α"},
+		{"el upper latin acute kept", LocaleGreek, true, "é", "É"},
+		{"el lower", LocaleGreek, false, "ΟΔΥΣΣΕΥΣ", "οδυσσευς"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			if tt.upper {
+				got = ToUpperSpecial(tt.loc, tt.s)
+			} else {
+				got = ToLowerSpecial(tt.loc, tt.s)
+			}
+			if got != tt.want {
+				t.Fatalf("%s(%q) = %q, want %q", tt.loc, tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpecialCaseASCIIFastPath(t *testing.T) {
+	for _, loc := range []Locale{LocaleRoot, LocaleTurkish, LocaleLithuanian, LocaleGreek} {
+		s := Repeat("HELLO-WORLD-", 100)
+		if got := ToUpperSpecial(loc, s); got != s {
+			t.Fatalf("%s: ToUpperSpecial changed upper-case ASCII input", loc)
+		}
+		if got, want := ToLowerSpecial(loc, "HELLO WORLD"), "hello world"; loc != LocaleTurkish && got != want {
+			t.Fatalf("%s: ToLowerSpecial = %q, want %q", loc, got, want)
+		}
+	}
+	allocs := testing.AllocsPerRun(10, func() { ToUpperSpecial(LocaleRoot, "ALREADY UPPER") })
+	if allocs != 0 {
+		t.Fatalf("expected 0 allocs, got %v", allocs)
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	tests := map[string]Locale{
+		"":      LocaleRoot,
+		"en-US": LocaleRoot,
+		"tr":    LocaleTurkish,
+		"TR-tr": LocaleTurkish,
+		"az_AZ": LocaleAzeri,
+		"lt":    LocaleLithuanian,
+		"el-GR": LocaleGreek,
+		"elx":   LocaleRoot,
+	}
+	for tag, want := range tests {
+		if got := ParseLocale(tag); got != want {
+			t.Errorf("ParseLocale(%q) = %v, want %v", tag, got, want)
+		}
+	}
+}
+
+func BenchmarkToUpperSpecialGerman(b *testing.B) {
+	s := strings.Repeat("Die Straße ist groß. ", 200)
+	b.ReportAllocs()
+	for b.Loop() {
+		ToUpperSpecial(LocaleRoot, s)
+	}
+}
+
+func TestCaseTablesSorted(t *testing.T) {
+	for i := 1; i < len(specialUpper); i++ {
+		if specialUpper[i-1].r >= specialUpper[i].r {
+			t.Fatalf("specialUpper not sorted at %d", i)
+		}
+	}
+	for i := 1; i < len(greekUpper); i++ {
+		if greekUpper[i-1].r >= greekUpper[i].r {
+			t.Fatalf("greekUpper not sorted at %d", i)
+		}
+	}
+}
@@ -349,181 +349,118 @@ func Repeat(s string, count int) string {
 	return b.String()
 }
 
+// EqualFold reports whether b and s, interpreted as UTF-8 strings, are
+// equal under simple Unicode case folding, like strings.EqualFold. Strings
+// of different byte lengths can be equal, e.g. "\u212A" (Kelvin sign) and
+// "k", in agreement with IndexFold and the rest of the *Fold family.
 func EqualFold(b, s string) bool {
-	if len(b) != len(s) {
-		return false
-	}
-
-	table := upperTable
-	n := len(b)
-	i := 0
-
-	// Unroll by 4
-	limit := n &^ 3
-	for i < limit {
-		b0 := b[i+0]
-		s0 := s[i+0]
-		if b0|s0 >= utf8.RuneSelf {
-			goto hasUnicode
-		}
-		if table[b0] != table[s0] {
-			return false
-		}
-
-		b1 := b[i+1]
-		s1 := s[i+1]
-		if b1|s1 >= utf8.RuneSelf {
-			goto hasUnicode
-		}
-		if table[b1] != table[s1] {
-			return false
-		}
-
-		b2 := b[i+2]
-		s2 := s[i+2]
-		if b2|s2 >= utf8.RuneSelf {
-			goto hasUnicode
-		}
-		if table[b2] != table[s2] {
-			return false
-		}
-
-		b3 := b[i+3]
-		s3 := s[i+3]
-		if b3|s3 >= utf8.RuneSelf {
-			goto hasUnicode
-		}
-		if table[b3] != table[s3] {
-			return false
-		}
+	n, ok := foldPrefix(b, s)
+	return ok && n == len(b)
+}
 
-		i += 4
+// equalFoldRune reports whether br and sr are equal under simple Unicode
+// case folding. It is the rune comparison behind EqualFold and the rest
+// of the *Fold family.
+func equalFoldRune(br, sr rune) bool {
+	// Fast match
+	if br == sr {
+		return true
 	}
 
-	for i < n {
-		bi := b[i]
-		si := s[i]
-		if bi|si >= utf8.RuneSelf {
-			goto hasUnicode
-		}
-		if table[b[i]] != table[s[i]] {
-			return false
-		}
-		i++
+	// Make br < sr
+	if sr < br {
+		sr, br = br, sr
 	}
-	return true
-
-hasUnicode:
-	// Fall back to Unicode-aware path.
-	// Trim processed part.
-	b = b[i:]
-	s = s[i:]
-
-	for len(b) > 0 {
-		if len(s) == 0 {
-			return false
-		}
-
-		var br, sr rune
-		var bs, ss int
-
-		// decode b rune
-		if b[0] < utf8.RuneSelf {
-			br = rune(b[0])
-			bs = 1
-		} else {
-			br, bs = utf8.DecodeRune(unsafeBytes(b))
-		}
 
-		// decode s rune
-		if s[0] < utf8.RuneSelf {
-			sr = rune(s[0])
-			ss = 1
-		} else {
-			sr, ss = utf8.DecodeRune(unsafeBytes(s))
-		}
-
-		b = b[bs:]
-		s = s[ss:]
-
-		// Fast match
-		if br == sr {
-			continue
-		}
-
-		// Make br < sr
-		if sr < br {
-			sr, br = br, sr
-		}
+	// ASCII fast case
+	if sr < utf8.RuneSelf {
+		return 'A' <= br && br <= 'Z' && sr == br+'a'-'A'
+	}
 
-		// ASCII fast case
-		if sr < utf8.RuneSelf {
-			if 'A' <= br && br <= 'Z' && sr == br+'a'-'A' {
-				continue
-			}
-			return false
-		}
+	// unicode.SimpleFold
+	r := unicode.SimpleFold(br)
+	for r != br && r < sr {
+		r = unicode.SimpleFold(r)
+	}
+	return r == sr
+}
 
-		// unicode.SimpleFold
-		r := unicode.SimpleFold(br)
-		for r != br && r < sr {
-			r = unicode.SimpleFold(r)
-		}
-		if r == sr {
-			continue
-		}
+// nSmallInts is the number of non-negative integers formatInt and
+// formatUint return from smallInts.
+const nSmallInts = 1000
+
+// smallInts holds the decimal forms of 0 to nSmallInts-1, all slices of
+// one string built once, so formatting them never allocates.
+var smallInts = func() *[nSmallInts]string {
+	var all []byte
+	var ends [nSmallInts]int
+	for i := range nSmallInts {
+		all = strconv.AppendInt(all, int64(i), 10)
+		ends[i] = len(all)
+	}
+	s := string(all)
+	var table [nSmallInts]string
+	start := 0
+	for i, end := range ends {
+		table[i] = s[start:end]
+		start = end
+	}
+	return &table
+}()
 
-		return false
+// formatInt returns the decimal form of v. Small values come from
+// smallInts; others are formatted on the stack and copied into a string
+// of their own, so the result never refers to memory owned by anyone else.
+func formatInt(v int64) string {
+	if 0 <= v && v < nSmallInts {
+		return smallInts[v]
 	}
+	var buf [strconv2.SAFETY_BUF_SIZE]byte
+	n := strconv2.FormatInt6410(buf[:], v)
+	return string(buf[:n])
+}
 
-	return len(s) == 0
+// formatUint is formatInt for unsigned values.
+func formatUint(v uint64) string {
+	if v < nSmallInts {
+		return smallInts[v]
+	}
+	var buf [strconv2.SAFETY_BUF_SIZE]byte
+	n := strconv2.FormatUint6410(buf[:], v)
+	return string(buf[:n])
 }
 
 // ToString Change arg to string
+//
+// Types without a built-in conversion are formatted by a formatter
+// registered with RegisterFormatter, if any, then by their String method,
+// then by reflection.
 func ToString(arg any, timeFormat ...string) string {
+	return toString(arg, formatOptions{timeFormat: timeFormat})
+}
+
+func toString(arg any, o formatOptions) string {
 	switch v := arg.(type) {
 	case int:
-		var buf [strconv2.SAFETY_BUF_SIZE]byte
-		n := strconv2.FormatInt6410(buf[:], int64(v))
-		return unsafeString(buf[:n])
+		return formatInt(int64(v))
 	case int8:
-		var buf [strconv2.SAFETY_BUF_SIZE]byte
-		n := strconv2.FormatInt6410(buf[:], int64(v))
-		return unsafeString(buf[:n])
-
+		return formatInt(int64(v))
 	case int16:
-		var buf [strconv2.SAFETY_BUF_SIZE]byte
-		n := strconv2.FormatInt6410(buf[:], int64(v))
-		return unsafeString(buf[:n])
+		return formatInt(int64(v))
 	case int32:
-		var buf [strconv2.SAFETY_BUF_SIZE]byte
-		n := strconv2.FormatInt6410(buf[:], int64(v))
-		return unsafeString(buf[:n])
+		return formatInt(int64(v))
 	case int64:
-		var buf [strconv2.SAFETY_BUF_SIZE]byte
-		n := strconv2.FormatInt6410(buf[:], int64(v))
-		return unsafeString(buf[:n])
+		return formatInt(v)
 	case uint:
-		var buf [strconv2.SAFETY_BUF_SIZE]byte
-		n := strconv2.FormatUint6410(buf[:], uint64(v))
-		return unsafeString(buf[:n])
+		return formatUint(uint64(v))
 	case uint8:
-		var buf [strconv2.SAFETY_BUF_SIZE]byte
-		n := strconv2.FormatUint6410(buf[:], uint64(v))
-		return unsafeString(buf[:n])
+		return formatUint(uint64(v))
 	case uint16:
-		var buf [strconv2.SAFETY_BUF_SIZE]byte
-		n := strconv2.FormatUint16(buf[:], v)
-		return unsafeString(buf[:n])
+		return formatUint(uint64(v))
 	case uint32:
-		var buf [strconv2.SAFETY_BUF_SIZE]byte
-		n := strconv2.FormatUint6410(buf[:], uint64(v))
-		return unsafeString(buf[:n])
-
+		return formatUint(uint64(v))
 	case uint64:
-		var buf [strconv2.SAFETY_BUF_SIZE]byte
-		n := strconv2.FormatUint6410(buf[:], uint64(v))
-		return unsafeString(buf[:n])
+		return formatUint(v)
 	case string:
 		return v
 	case []byte:
@@ -535,32 +472,49 @@ func ToString(arg any, timeFormat ...string) string {
 	case float64:
 		return strconv.FormatFloat(v, 'f', -1, 64)
 	case time.Time:
-		if len(timeFormat) > 0 {
-			return v.Format(timeFormat[0])
+		if f, ok := o.formatter(arg); ok {
+			return f(arg)
+		}
+		if len(o.timeFormat) > 0 {
+			return v.Format(o.timeFormat[0])
 		}
 		return v.Format("2006-01-02 15:04:05")
 	case reflect.Value:
-		return ToString(v.Interface(), timeFormat...)
+		return toString(v.Interface(), o)
+	}
+	if f, ok := o.formatter(arg); ok {
+		return f(arg)
+	}
+	rv := reflect.ValueOf(arg)
+	nilPtr := rv.Kind() == reflect.Pointer && rv.IsNil()
+	if rv.Kind() == reflect.Pointer && !nilPtr {
+		// A formatter for T also wins over String methods of *T.
+		if f, ok := o.formatterType(rv.Type().Elem()); ok {
+			return f(rv.Elem().Interface())
+		}
+	}
+	switch v := arg.(type) {
+	case error:
+		if nilPtr {
+			return callNilSafe(v.Error)
+		}
+		return v.Error()
 	case fmt.Stringer:
-		return v.String()
-	default:
-		rv := reflect.ValueOf(arg)
-		if rv.Kind() == reflect.Pointer && !rv.IsNil() {
-			return ToString(rv.Elem().Interface(), timeFormat...)
-		} else if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
-			// handle slices
-			var buf = NewBuilder(rv.Len())
-			buf.WriteString("[") //nolint:errcheck // no need to check error
-			for i := 0; i < rv.Len(); i++ {
-				if i > 0 {
-					buf.WriteString(" ") //nolint:errcheck // no need to check error
-				}
-				buf.WriteString(ToString(rv.Index(i).Interface())) //nolint:errcheck // no need to check error
-			}
-			buf.WriteString("]") //nolint:errcheck // no need to check error
-			return buf.String()
+		if nilPtr {
+			return callNilSafe(v.String)
 		}
-
-		return fmt.Sprint(arg)
+		return v.String()
 	}
+	return formatValue(rv, o)
+}
+
+// callNilSafe calls a method of a nil pointer, which may handle a nil
+// receiver, and returns "<nil>" if it panics instead, as fmt does.
+func callNilSafe(method func() string) (s string) {
+	defer func() {
+		if recover() != nil {
+			s = "<nil>"
+		}
+	}()
+	return method()
 }
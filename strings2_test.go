@@ -1,13 +1,16 @@
 package strings2
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"reflect"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
+	"unsafe"
 
 	"github.com/NikoMalik/strconv2"
 )
@@ -410,6 +413,205 @@ func TestBuilderResetAndKeepCap(t *testing.T) {
 	})
 }
 
+func TestBuilderWriteFormatted(t *testing.T) {
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	b := NewBuilder(8)
+	b.WriteInt(-42)
+	b.WriteByte(' ')
+	b.WriteInt(math.MinInt64)
+	b.WriteByte(' ')
+	b.WriteUint(math.MaxUint64)
+	b.WriteByte(' ')
+	b.WriteHex(0xdeadbeef)
+	b.WriteByte(' ')
+	b.WriteFloat(3.25, 'f', -1, 64)
+	b.WriteByte(' ')
+	b.WriteFloat(1.0/3, 'e', 3, 32)
+	b.WriteByte(' ')
+	b.WriteBool(true)
+	b.WriteByte(' ')
+	b.WriteTime(tm, time.RFC3339)
+	b.WriteByte(' ')
+	b.WriteQuoted("a\"b\n")
+
+	want := fmt.Sprintf("%d %d %d %x %v %.3e %v %s %q",
+		-42, int64(math.MinInt64), uint64(math.MaxUint64), 0xdeadbeef, 3.25, float32(1.0/3), true, tm.Format(time.RFC3339), "a\"b\n")
+	if got := b.String(); got != want {
+		t.Fatalf("\ngot : %q\nwant: %q", got, want)
+	}
+}
+
+func TestBuilderWriteFormattedNoAlloc(t *testing.T) {
+	b := NewBuilder(256)
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	allocs := testing.AllocsPerRun(100, func() {
+		b.ResetAndKeepCap()
+		b.WriteInt(123456789)
+		b.WriteUint(987654321)
+		b.WriteFloat(2.5, 'g', -1, 64)
+		b.WriteBool(false)
+		b.WriteHex(255)
+		b.WriteTime(tm, time.DateTime)
+		b.WriteQuoted("quoted")
+	})
+	if allocs != 0 {
+		t.Fatalf("expected 0 allocs, got %v", allocs)
+	}
+}
+
+func TestBuilderDetach(t *testing.T) {
+	b := NewBuilder(32)
+	b.WriteString("secret123")
+	s := b.Detach()
+	if s != "secret123" || b.Len() != 0 || b.Cap() != 0 {
+		t.Fatalf("Detach: s=%q len=%d cap=%d", s, b.Len(), b.Cap())
+	}
+
+	b.ResetAndKeepCap()
+	b.WriteString("overwrite")
+	b.ResetAndKeepCap()
+	if s != "secret123" {
+		t.Fatalf("detached string changed to %q", s)
+	}
+
+	p := NewBuilderPool(0, true)
+	pb := p.Get(64)
+	pb.WriteString("pooled")
+	s = pb.Detach()
+	p.Put(pb)
+	if s != "pooled" {
+		t.Fatalf("detached string changed to %q after Put", s)
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		b := NewBuilder(16)
+		b.WriteString("abc")
+		s = b.Detach()
+	})
+	if allocs != 1 {
+		t.Fatalf("expected 1 alloc, got %v", allocs)
+	}
+}
+
+func TestBuilderEdit(t *testing.T) {
+	b := NewBuilder(8)
+	b.WriteString("a,b,c,")
+	b.Truncate(b.Len() - 1)
+	if got := b.String(); got != "a,b,c" {
+		t.Fatalf("Truncate: %q", got)
+	}
+
+	b.WriteString(" \t\n")
+	b.TrimRight(" \t\n")
+	if got := b.String(); got != "a,b,c" {
+		t.Fatalf("TrimRight: %q", got)
+	}
+	b.WriteString("жжж")
+	b.TrimRight("ж")
+	if got := b.String(); got != "a,b,c" {
+		t.Fatalf("TrimRight unicode: %q", got)
+	}
+
+	b.InsertString(0, "header: ")
+	if got := b.String(); got != "header: a,b,c" {
+		t.Fatalf("InsertString: %q", got)
+	}
+	b.InsertString(b.Len(), "!")
+	b.Delete(0, len("header: "))
+	if got := b.String(); got != "a,b,c!" {
+		t.Fatalf("Delete: %q", got)
+	}
+
+	b.ReplaceRange(1, 2, " and ")
+	b.ReplaceRange(0, 1, "x")
+	b.ReplaceRange(b.Len()-2, b.Len(), "")
+	if got := b.String(); got != "x and b," {
+		t.Fatalf("ReplaceRange: %q", got)
+	}
+	b.SetByte(b.Len()-1, '.')
+	if got := b.String(); got != "x and b." {
+		t.Fatalf("SetByte: %q", got)
+	}
+
+	for _, f := range []func(){
+		func() { b.Truncate(-1) },
+		func() { b.Truncate(b.Len() + 1) },
+		func() { b.Delete(2, 1) },
+		func() { b.InsertString(b.Len()+1, "x") },
+		func() { b.ReplaceRange(-1, 0, "x") },
+		func() { b.SetByte(b.Len(), 'x') },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestBuilderReadFromWriteTo(t *testing.T) {
+	src := strings.Repeat("streaming data ", 1000)
+
+	b := NewBuilder(0)
+	b.WriteString("head:")
+	n, err := io.Copy(b, strings.NewReader(src))
+	if err != nil || n != int64(len(src)) {
+		t.Fatalf("io.Copy into builder: n=%d err=%v", n, err)
+	}
+	if got, want := b.String(), "head:"+src; got != want {
+		t.Fatal("ReadFrom content mismatch")
+	}
+
+	var out strings.Builder
+	n, err = b.WriteTo(&out)
+	if err != nil || n != int64(b.Len()) {
+		t.Fatalf("WriteTo: n=%d err=%v", n, err)
+	}
+	if out.String() != b.String() {
+		t.Fatal("WriteTo content mismatch")
+	}
+	if b.Len() != len("head:")+len(src) {
+		t.Fatal("WriteTo must not consume the builder")
+	}
+
+	if string(b.Bytes()) != b.String() {
+		t.Fatal("Bytes mismatch")
+	}
+	rd, err := io.ReadAll(b.Reader())
+	if err != nil || string(rd) != b.String() {
+		t.Fatalf("Reader mismatch: err=%v", err)
+	}
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read(p []byte) (int, error) {
+	copy(p, "abc")
+	return 3, r.err
+}
+
+type shortWriter struct{}
+
+func (shortWriter) Write(p []byte) (int, error) { return len(p) / 2, nil }
+
+func TestBuilderReadFromWriteToErrors(t *testing.T) {
+	b := NewBuilder(0)
+	boom := errors.New("boom")
+	n, err := b.ReadFrom(errReader{boom})
+	if err != boom || n != 3 || b.String() != "abc" {
+		t.Fatalf("ReadFrom: n=%d err=%v content=%q", n, err, b.String())
+	}
+
+	n, err = b.WriteTo(shortWriter{})
+	if err != io.ErrShortWrite || n != 1 {
+		t.Fatalf("WriteTo: n=%d err=%v", n, err)
+	}
+}
+
 func Test_EqualFold(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -425,15 +627,25 @@ func Test_EqualFold(t *testing.T) {
 		{Expected: false, S1: "\na", S2: "*A"},
 		{Expected: true, S1: "/MY3/NAME/IS/:PARAM/*", S2: "/my3/name/is/:param/*"},
 		{Expected: true, S1: "/MY4/NAME/IS/:PARAM/*", S2: "/my4/nAME/IS/:param/*"},
+		{Expected: true, S1: "\u212A", S2: "k"},
+		{Expected: true, S1: "k", S2: "\u212A"},
+		{Expected: true, S1: "abcd\u212Aelvin", S2: "ABCDKELVIN"},
+		{Expected: true, S1: "\u017Ftra\u00DFe", S2: "STRA\u00DFE"},
+		{Expected: false, S1: "abc", S2: "abcd"},
+		{Expected: false, S1: "k", S2: "\u212A\u212A"},
+		{Expected: false, S1: "", S2: "k"},
 	}
 
 	for _, tc := range testCases {
 		got := EqualFold(tc.S1, tc.S2)
 		want := strings.EqualFold(tc.S1, tc.S2)
-		if want != got {
+		if want != got || got != tc.Expected {
 			t.Fatalf("Equal Fold: mismatch: %s:%s", tc.S1, tc.S2)
 
 		}
+		if got && !(ContainsFold(tc.S1, tc.S2) && HasPrefixFold(tc.S2, tc.S1)) {
+			t.Fatalf("Equal Fold: %q and %q disagree with the *Fold family", tc.S1, tc.S2)
+		}
 
 	}
 }
@@ -579,6 +791,49 @@ func TestToStringStackEscape(t *testing.T) {
 	}
 }
 
+func TestToStringInts(t *testing.T) {
+	cases := []any{
+		0, 1, 9, 10, 99, 100, 999, 1000, 1001, -1, -999, -1000,
+		int8(-128), int8(127), int16(-32768), int32(math.MinInt32), int64(math.MinInt64), int64(math.MaxInt64),
+		uint(0), uint8(255), uint16(999), uint16(65535), uint32(math.MaxUint32), uint64(math.MaxUint64),
+	}
+	for _, v := range cases {
+		if got, want := ToString(v), fmt.Sprint(v); got != want {
+			t.Errorf("ToString(%T(%v)) = %q, want %q", v, v, got, want)
+		}
+	}
+	for i := range nSmallInts {
+		if got, want := smallInts[i], strconv.Itoa(i); got != want {
+			t.Fatalf("smallInts[%d] = %q", i, got)
+		}
+	}
+}
+
+func TestToStringIntOwnership(t *testing.T) {
+	// Small values share the static table and never allocate.
+	if unsafe.StringData(ToString(42)) != unsafe.StringData(ToString(uint8(42))) {
+		t.Fatal("small ints should come from the shared table")
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		ToString(0)
+		ToString(999)
+		ToString(uint16(7))
+	})
+	if allocs != 0 {
+		t.Fatalf("expected 0 allocs for small ints, got %v", allocs)
+	}
+
+	// Larger values get memory of their own.
+	a, b := ToString(123456789), ToString(123456789)
+	if unsafe.StringData(a) == unsafe.StringData(b) {
+		t.Fatal("large ints must not share memory")
+	}
+	allocs = testing.AllocsPerRun(100, func() { ToString(-123456789) })
+	if allocs != 1 {
+		t.Fatalf("expected 1 alloc for a large int, got %v", allocs)
+	}
+}
+
 func TestToStringByteAlias(t *testing.T) {
 	b := []byte("hello")
 	s := ToString(b)
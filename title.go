@@ -0,0 +1,192 @@
+package strings2
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ToTitle returns s with all Unicode letters mapped to their title case.
+// For ASCII input title case is upper case, so it shares the ToUpper fast
+// path.
+func ToTitle(s string) string {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return strings.Map(unicode.ToTitle, s)
+		}
+	}
+	return ToUpper(s)
+}
+
+// WordBoundary selects where TitleWords considers a new word to start.
+type WordBoundary uint8
+
+const (
+	// WordBoundarySpace separates words by white space only.
+	WordBoundarySpace WordBoundary = iota
+	// WordBoundaryPunct separates words by white space, punctuation and
+	// symbols, except apostrophes inside a word: "rock-n-roll" becomes
+	// "Rock-N-Roll", "don't" stays one word.
+	WordBoundaryPunct
+	// WordBoundaryUnicode follows the word boundaries of UAX #29: letters,
+	// digits, marks and connectors like '_' form words, and an apostrophe,
+	// period or colon between two of them does not split the word, so
+	// "o'neil" and "example.com" are one word each.
+	WordBoundaryUnicode
+)
+
+// asciiWordSep[boundary][c] reports that the ASCII byte c separates words.
+// Mid-word punctuation is resolved afterwards by isMidLetter.
+var asciiWordSep = func() [3][utf8.RuneSelf]bool {
+	var table [3][utf8.RuneSelf]bool
+	for i := range utf8.RuneSelf {
+		c := byte(i)
+		alnum := lowerTable[c] != upperTable[c] || ('0' <= c && c <= '9')
+		table[WordBoundarySpace][i] = c == ' ' || ('\t' <= c && c <= '\r')
+		table[WordBoundaryPunct][i] = !alnum
+		table[WordBoundaryUnicode][i] = !alnum && c != '_'
+	}
+	return table
+}()
+
+// TitleWords returns s with the first letter of each word mapped to its
+// title case, using the full mappings of SpecialCasing.txt ("ßa" becomes
+// "Ssa"). The rest of each word is left unchanged. Words are delimited
+// according to boundary. If nothing changes, s itself is returned.
+//
+// TitleWords is a replacement for the deprecated strings.Title.
+func TitleWords(s string, boundary WordBoundary) string {
+	if boundary > WordBoundaryUnicode {
+		boundary = WordBoundaryUnicode
+	}
+	return titleWords(s, boundary, nil)
+}
+
+// TitleWordsFunc is like TitleWords, with words delimited by the runes for
+// which isSeparator returns true.
+func TitleWordsFunc(s string, isSeparator func(rune) bool) string {
+	return titleWords(s, 0, isSeparator)
+}
+
+func titleWords(s string, boundary WordBoundary, isSeparator func(rune) bool) string {
+	var b *Builder // lazy result
+	last := 0
+	inWord := false
+	sepTable := &asciiWordSep[boundary]
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		r, wid := rune(c), 1
+		if c >= utf8.RuneSelf {
+			r, wid = utf8.DecodeRuneInString(s[i:])
+		}
+
+		var sep bool
+		switch {
+		case isSeparator != nil:
+			sep = isSeparator(r)
+		case c < utf8.RuneSelf:
+			sep = sepTable[c]
+		default:
+			sep = isWordSep(r, boundary)
+		}
+		if sep && inWord && isSeparator == nil && joinsWord(r, boundary) {
+			sep = !isMidLetter(s, i, wid)
+		}
+
+		if sep {
+			inWord = false
+			i += wid
+			continue
+		}
+		if inWord {
+			i += wid
+			continue
+		}
+		inWord = true
+
+		// First rune of a word.
+		if c < utf8.RuneSelf {
+			if uc := upperTable[c]; uc != c {
+				if b == nil {
+					b = NewBuilder(len(s))
+				}
+				b.WriteString(s[last:i])
+				b.WriteByte(uc)
+				last = i + 1
+			}
+			i++
+			continue
+		}
+		if !unicode.IsLetter(r) {
+			i += wid
+			continue
+		}
+		to, special := lookupSpecial(specialTitle[:], r)
+		if !special {
+			tr := unicode.ToTitle(r)
+			if tr == r {
+				i += wid
+				continue
+			}
+			to = string(tr)
+		}
+		if b == nil {
+			b = NewBuilder(len(s) + len(s)>>3)
+		}
+		b.WriteString(s[last:i])
+		b.WriteString(to)
+		i += wid
+		last = i
+	}
+
+	if b == nil {
+		return s //zerocalloc
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// isWordSep reports whether the non-ASCII rune r separates words.
+func isWordSep(r rune, boundary WordBoundary) bool {
+	switch boundary {
+	case WordBoundarySpace:
+		return unicode.IsSpace(r)
+	case WordBoundaryPunct:
+		return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	}
+	return !isWordRune(r)
+}
+
+// isWordRune reports whether r belongs to a word under UAX #29.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || unicode.Is(unicode.Pc, r)
+}
+
+// joinsWord reports whether r keeps a word together when it sits between
+// two word runes: apostrophes for WordBoundaryPunct, and the UAX #29
+// MidLetter, MidNumLet and Single_Quote runes for WordBoundaryUnicode.
+func joinsWord(r rune, boundary WordBoundary) bool {
+	switch boundary {
+	case WordBoundaryPunct:
+		return r == '\'' || r == '\u2019'
+	case WordBoundaryUnicode:
+		switch r {
+		case '\'', '.', ':', '\u00B7', '\u0387', '\u2018', '\u2019', '\u2024', '\u2027',
+			'\uFE13', '\uFE52', '\uFE55', '\uFF07', '\uFF0E', '\uFF1A':
+			return true
+		}
+	}
+	return false
+}
+
+// isMidLetter reports whether the rune at s[i:i+wid] sits between two
+// word runes (UAX #29 WB6, WB7, WB11 and WB12).
+func isMidLetter(s string, i, wid int) bool {
+	if i == 0 || i+wid >= len(s) {
+		return false
+	}
+	prev, _ := utf8.DecodeLastRuneInString(s[:i])
+	next, _ := utf8.DecodeRuneInString(s[i+wid:])
+	return isWordRune(prev) && isWordRune(next) && prev != '_' && next != '_'
+}
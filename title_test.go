@@ -0,0 +1,93 @@
+package strings2
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+	"unsafe"
+)
+
+func TestToTitle(t *testing.T) {
+	tests := []string{
+		"hello world",
+		"HELLO",
+		"привет мир",
+		"ǆemal", // has a distinct title case
+		"你好 世界",
+		strings.Repeat("abc", 1000),
+	}
+	for _, s := range tests {
+		if got, want := ToTitle(s), strings.ToTitle(s); got != want {
+			t.Errorf("ToTitle(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func TestTitleWords(t *testing.T) {
+	tests := []struct {
+		s        string
+		boundary WordBoundary
+		want     string
+	}{
+		{"hello world", WordBoundarySpace, "Hello World"},
+		{"hello\tworld\nagain", WordBoundarySpace, "Hello\tWorld\nAgain"},
+		{"rock-n-roll", WordBoundarySpace, "Rock-n-roll"},
+		{"rock-n-roll", WordBoundaryPunct, "Rock-N-Roll"},
+		{"rock-n-roll", WordBoundaryUnicode, "Rock-N-Roll"},
+		{"don't stop", WordBoundaryPunct, "Don't Stop"},
+		{"don't stop", WordBoundaryUnicode, "Don't Stop"},
+		{"don’t stop", WordBoundaryPunct, "Don’t Stop"},
+		{"'quoted' words", WordBoundaryPunct, "'Quoted' Words"},
+		{"'quoted' words", WordBoundaryUnicode, "'Quoted' Words"},
+		{"visit example.com now", WordBoundaryPunct, "Visit Example.Com Now"},
+		{"visit example.com now", WordBoundaryUnicode, "Visit Example.com Now"},
+		{"snake_case name", WordBoundaryPunct, "Snake_Case Name"},
+		{"snake_case name", WordBoundaryUnicode, "Snake_case Name"},
+		{"3rd place", WordBoundaryUnicode, "3rd Place"},
+		{"привет мир", WordBoundarySpace, "Привет Мир"},
+		{"élan vital", WordBoundaryUnicode, "Élan Vital"},
+		{"ǆemal", WordBoundarySpace, "ǅemal"},
+		{"ßa ﬁsh", WordBoundarySpace, "Ssa Fish"},
+		{"hELLO wORLD", WordBoundarySpace, "HELLO WORLD"},
+		{"", WordBoundaryUnicode, ""},
+		{"   ", WordBoundaryUnicode, "   "},
+		{"a", WordBoundaryUnicode, "A"},
+		{"hello, world!", WordBoundaryUnicode, "Hello, World!"},
+		{"你好 世界", WordBoundaryUnicode, "你好 世界"},
+	}
+	for _, tt := range tests {
+		if got := TitleWords(tt.s, tt.boundary); got != tt.want {
+			t.Errorf("TitleWords(%q, %d) = %q, want %q", tt.s, tt.boundary, got, tt.want)
+		}
+	}
+}
+
+func TestTitleWordsFunc(t *testing.T) {
+	got := TitleWordsFunc("path/to/some-file", func(r rune) bool { return r == '/' })
+	if want := "Path/To/Some-file"; got != want {
+		t.Fatalf("TitleWordsFunc = %q, want %q", got, want)
+	}
+	got = TitleWordsFunc("привет,мир", unicode.IsPunct)
+	if want := "Привет,Мир"; got != want {
+		t.Fatalf("TitleWordsFunc = %q, want %q", got, want)
+	}
+}
+
+func TestTitleWordsUnchanged(t *testing.T) {
+	s := "Already Title Cased"
+	if got := TitleWords(s, WordBoundaryUnicode); unsafe.StringData(got) != unsafe.StringData(s) {
+		t.Fatal("expected the input string to be returned unchanged")
+	}
+	allocs := testing.AllocsPerRun(10, func() { TitleWords(s, WordBoundaryPunct) })
+	if allocs != 0 {
+		t.Fatalf("expected 0 allocs, got %v", allocs)
+	}
+}
+
+func BenchmarkTitleWords(b *testing.B) {
+	s := strings.Repeat("the quick brown fox jumps over the lazy dog ", 100)
+	b.ReportAllocs()
+	for b.Loop() {
+		TitleWords(s, WordBoundaryUnicode)
+	}
+}